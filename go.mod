@@ -5,7 +5,7 @@ go 1.25.0
 require github.com/charmbracelet/huh/spinner v0.0.0-20250826160502-fa7f8a27cd5c
 
 require (
-	github.com/atotto/clipboard v0.1.4 // indirect
+	github.com/atotto/clipboard v0.1.4
 	github.com/catppuccin/go v0.3.0 // indirect
 	github.com/charmbracelet/x/exp/strings v0.0.0-20250904123553-b4e2667e5ad5 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
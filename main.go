@@ -3,32 +3,33 @@ package main
 import (
 	"log"
 	"os"
-	"time"
 
 	"github.com/kurianvarkey/gitcommitui/src/cmd"
-	"github.com/kurianvarkey/gitcommitui/src/handlers"
 	"github.com/kurianvarkey/gitcommitui/src/helpers"
 )
 
 // main is the entry point of the application.
 //
-// It initialises the application, then runs the main loop which involves
-// checking for changed files, staging changes, prompting the user for a
-// commit message, committing the changes, and prompting the user to push
-// the branch to origin.
+// It runs the command named on the command line. Without a command it runs
+// the commit wizard, which involves checking for changed files, staging
+// changes, prompting the user for a commit message, committing the changes,
+// and prompting the user to push the branch to origin. Run
+// "git-commit-ui help" for the commands and flags.
 //
-// If any step fails, it logs the error and exits with a non-zero status code.
+// Giving any of the commit flags (-type, -version, -ref, -summary, -body,
+// -stage, -push, -yes) commits without prompting: the message comes from the
+// flags, confirmations are answered from -yes, and the commit is pushed only
+// with -push.
+//
+// If any step fails, it logs the error and exits with the code of the error:
+// 2 when the user cancels, 3 when there is nothing to commit, 4 for config
+// errors, 5 when the commit fails, 6 when the push fails, 7 for invalid
+// commands or flags and 1 otherwise.
 func main() {
 	log.SetFlags(0)
 
-	helpers.ShowSpinner("Initialising...", func() {
-		time.Sleep(1 * time.Second)
-	})
-
-	err := cmd.RunApp(&helpers.DefaultGitHelper{}, &handlers.DefaultCommitForm{})
-	if err != nil {
+	if err := cmd.Execute(&helpers.DefaultGitHelper{}, os.Args[1:]); err != nil {
 		log.Println("Exiting application:", err)
+		os.Exit(cmd.ExitCode(err))
 	}
-
-	os.Exit(0)
 }
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// RunAmend runs the amend mode. It reads the message of the last commit, parses
+// it back into the form, optionally includes newly staged changes and amends
+// the commit. It warns before rewriting a commit that was already pushed.
+func RunAmend(gitHelper helpers.GitHelper, form handlers.CommitForm, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	if err := options.applySigning(gitHelper, config); err != nil {
+		return err
+	}
+
+	message, err := handlers.GetLastCommitMessage(gitHelper)
+	if err != nil {
+		return err
+	}
+
+	upstream, pushed := handlers.IsHeadPushed(gitHelper)
+	if pushed {
+		if !gitHelper.ShowConfirm(fmt.Sprintf("The last commit has already been pushed to '%s'. Amending it rewrites published history. Do you want to continue?", upstream), false) {
+			return canceled("amend")
+		}
+	}
+
+	includeStaged := false
+	stagedFiles, exit := handlers.GetStagedFiles(gitHelper)
+	if exit {
+		return canceled("amend")
+	}
+	if len(stagedFiles) > 0 {
+		includeStaged = gitHelper.ShowConfirm("Include the staged files in the amended commit?", true)
+	}
+
+	if !handlers.PrefillAmendForm(config, form, message) {
+		log.Println("The last commit message does not match the commit format, using it as the summary.")
+	}
+
+	if lookupSetter, ok := form.(handlers.ReferenceLookupSetter); ok {
+		lookupSetter.SetReferenceLookup(newReferenceLookup(gitHelper, config))
+	}
+
+	if err := handlers.ShowAmendUI(gitHelper, config, form, includeStaged); err != nil {
+		return commitError(err)
+	}
+
+	if pushed {
+		return options.forcePushAmend(gitHelper, config, upstream)
+	}
+
+	return nil
+}
+
+// forcePushAmend offers to replace the pushed commit on the upstream with the
+// amended one using a force push with lease. Protected branches are never
+// force pushed. It returns an error when the force push fails.
+func (o *Options) forcePushAmend(gitHelper helpers.GitHelper, config *settings.Config, upstreamName string) error {
+	branchName, err := handlers.GetCurrentBranch(gitHelper)
+	if err != nil {
+		return nil
+	}
+
+	upstream := handlers.GetUpstream(gitHelper, branchName)
+	if upstream == nil {
+		return nil
+	}
+
+	if !handlers.CanForcePush(config, branchName, upstream.Branch) {
+		fmt.Fprintf(o.out, "'%s' is protected and cannot be force pushed, the amended commit was kept locally\n", upstreamName)
+		return nil
+	}
+
+	if !gitHelper.ShowConfirm(fmt.Sprintf("Do you want to replace the pushed commit on '%s' with a force push with lease?", upstreamName), false) {
+		return nil
+	}
+
+	if err := handlers.ForcePushWithLease(gitHelper, config, upstream.Remote, branchName, upstream.Branch); err != nil {
+		return withKind(ErrPushFailed, fmt.Errorf("failed to force push: %w", err))
+	}
+
+	fmt.Fprintf(o.out, "Force pushed to %s successfully\n", upstreamName)
+	return nil
+}
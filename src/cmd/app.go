@@ -2,68 +2,452 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/forges"
 	"github.com/kurianvarkey/gitcommitui/src/handlers"
 	"github.com/kurianvarkey/gitcommitui/src/helpers"
 	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
 )
 
+// errNoChangedFiles is returned when the working tree has no changes to commit.
+var errNoChangedFiles = withKind(ErrNothingToCommit, errors.New("no changed files"))
+
 // RunApp is the main entrypoint for the application. It takes a Git helper and
 // a commit form as arguments and runs the application logic. It loads the
 // configuration, checks for changed files, shows the commit user interface,
 // and pushes the changes to the remote repository. If the user cancels at
-// any point, it returns an error.
-func RunApp(gitHelper helpers.GitHelper, form handlers.CommitForm) error {
-	config, err := settings.LoadConfig()
+// any point, it returns an error. Options given on the command line override
+// the values from the config.
+func RunApp(gitHelper helpers.GitHelper, form handlers.CommitForm, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("error loading config: %w", err)
+		return err
 	}
 
 	// Step 1: check whether git is initialised
-	if handlers.CheckForGitInitialise(gitHelper) {
-		return fmt.Errorf("not a git repository")
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	options.events.emitRepoDetected(gitHelper)
+
+	if err := options.applySigning(gitHelper, config); err != nil {
+		return err
+	}
+
+	// Step 2: check for changed files, offering the branch screen instead when
+	// an interactive run has nothing to commit
+	if !options.NonInteractive && !handlers.HasUncommittedChanges(gitHelper) {
+		return options.offerBranchScreen(gitHelper, config)
+	}
+
+	changedFiles, err := options.collectChangedFiles(gitHelper)
+	if err != nil {
+		return err
+	}
+	options.events.emit(Event{Event: EventFilesFound, Files: changedFiles})
+	options.events.emitStagedFiles(gitHelper)
+
+	history := handlers.LoadFormHistory(gitHelper, config)
+	defaultVersion, defaultJira := history.Defaults(config)
+
+	commitType, typeHint := handlers.SuggestCommitType(config, changedFiles)
+	form.SetDefaultValues(config.CommitTypes, commitType, defaultVersion, defaultJira)
+	if hinted, ok := form.(handlers.TypeHintSetter); ok {
+		hinted.SetTypeHint(typeHint)
+	}
+	if recaller, ok := form.(handlers.HistoryRecaller); ok {
+		recaller.SetHistory(history)
+	}
+	if lookupSetter, ok := form.(handlers.ReferenceLookupSetter); ok {
+		lookupSetter.SetReferenceLookup(newReferenceLookup(gitHelper, config))
+	}
+	coAuthorForm, hasCoAuthors := form.(handlers.CoAuthorForm)
+	if hasCoAuthors {
+		coAuthorForm.SetCoAuthors(handlers.GetCoAuthorCandidates(gitHelper, config), history.CoAuthors)
+	}
+
+	if validator, ok := form.(handlers.FormValidator); ok && options.NonInteractive {
+		if err := validator.Validate(); err != nil {
+			return withKind(ErrUsage, err)
+		}
+	}
+
+	previousHead := options.events.headCommit(gitHelper)
+	restoreStash := options.stashUnselectedChanges(gitHelper)
+	err = handlers.ShowCommitUI(gitHelper, config, form)
+	restoreStash()
+	if err != nil {
+		return commitError(err)
+	}
+
+	options.events.emit(Event{Event: EventMessageComposed, Message: handlers.ComposeCommitMessage(config, form)})
+	if err := options.events.emitCommitted(gitHelper, previousHead); err != nil {
+		return err
+	}
+
+	version, commitType, jira, summary := form.GetValues()
+	history.Record(handlers.HistoryEntry{Version: version, CommitType: commitType, Jira: jira, Summary: summary})
+	if hasCoAuthors {
+		history.CoAuthors = coAuthorForm.GetCoAuthors()
+	}
+	if err := history.Save(); err != nil {
+		log.Printf("Failed to save form history: %v", err)
+	}
+
+	branchName, err := handlers.GetCurrentBranch(gitHelper)
+	if err != nil {
+		return fmt.Errorf("failed to determine current branch: %w", err)
+	}
+
+	if options.NonInteractive && !options.Push {
+		return nil
+	}
+
+	return pushBranch(gitHelper, config, options, branchName)
+}
+
+// pushBranch shows the upstream status, asks which remotes to push the branch
+// to and pushes it, recovering rejected pushes and opening a pull request when
+// enabled. It returns an error when no push succeeded or some failed.
+func pushBranch(gitHelper helpers.GitHelper, config *settings.Config, options *Options, branchName string) error {
+	upstream := options.showUpstreamStatus(gitHelper, config, branchName)
+
+	remotes, err := listRemotes(gitHelper)
+	if err != nil {
+		return withKind(ErrPushFailed, err)
+	}
+
+	// A scripted run fails before pushing rather than open a pull request
+	// it did not ask for
+	if config.PullRequest.Enabled {
+		if err := helpers.RequireConfirmation(pullRequestPrompt(branchName)); err != nil {
+			return err
+		}
+	}
+
+	selected, ok := options.selectPushRemotes(gitHelper, branchName, remotes)
+	if !ok && options.NonInteractive {
+		return withKind(ErrPushFailed, fmt.Errorf("no remote to push '%s' to", branchName))
+	}
+	if !ok {
+		return canceled("push")
+	}
+
+	for _, remote := range selected {
+		fmt.Fprintf(options.out, "Pushing to %s (%s)\n", remote.Name, remote.URL)
+	}
+
+	results := handlers.PushToRemotes(gitHelper, selected, branchName, upstream)
+	results = options.recoverRejectedPushes(gitHelper, config, results, branchName, upstream)
+	fmt.Fprintf(options.out, "Push summary:\n%s\n", handlers.PushSummary(results))
+	options.events.emitPushResults(results)
+
+	pushed, failed := splitPushResults(results)
+	if len(pushed) == 0 {
+		return withKind(ErrPushFailed, fmt.Errorf("failed to push to %s: %w", failed[0].Remote.Name, failed[0].Err))
+	}
+
+	if config.PullRequest.Enabled {
+		options.openPullRequest(gitHelper, config, pushed[0].Remote, branchName)
 	}
 
-	// Step 2: check for changed files
+	if len(failed) > 0 {
+		return withKind(ErrPushFailed, fmt.Errorf("failed to push to %d of %d remotes", len(failed), len(results)))
+	}
+
+	return nil
+}
+
+// commitError returns the error of the commit form: canceled when the user
+// canceled it, a usage error when a scripted run needed -yes, otherwise a
+// commit failure.
+func commitError(err error) error {
+	switch {
+	case errors.Is(err, handlers.ErrCommitCanceled):
+		return withKind(ErrCanceled, err)
+	case errors.Is(err, helpers.ErrConfirmationRequired):
+		return withKind(ErrUsage, err)
+	}
+	return withKind(ErrCommitFailed, err)
+}
+
+// collectChangedFiles returns the staged files, or stages and returns the
+// changed files when nothing is staged yet. It returns an error when there is
+// nothing to commit or the user declines.
+func collectChangedFiles(gitHelper helpers.GitHelper) ([]string, error) {
 	changedFiles, exit := handlers.GetStagedFiles(gitHelper)
 	if exit {
-		return fmt.Errorf("no staged files")
+		return nil, canceled("using the staged files")
 	}
 
 	if len(changedFiles) == 0 {
 		changedFiles, exit = handlers.GetChangedFiles(gitHelper)
-		if exit || len(changedFiles) == 0 {
-			return fmt.Errorf("no changed files")
+		if exit {
+			return nil, canceled("staging the changed files")
+		}
+		if len(changedFiles) == 0 {
+			return nil, errNoChangedFiles
 		}
 	}
 
-	form.SetDefaultValues(config.CommitTypes, config.DefaultCommitType, config.DefaultVersion, config.DefaultJiraReference)
+	return changedFiles, nil
+}
 
-	if !handlers.ShowCommitUI(gitHelper, config, form) {
-		return fmt.Errorf("user canceled commit UI")
+// collectChangedFiles returns the files to commit. Non-interactive runs with
+// a staging mode stage the changes as asked and commit what is staged;
+// otherwise the staged files are used, or the changes are staged when nothing
+// is staged yet, as in interactive runs.
+func (o *Options) collectChangedFiles(gitHelper helpers.GitHelper) ([]string, error) {
+	if !o.NonInteractive || o.Stage == "" {
+		return collectChangedFiles(gitHelper)
 	}
 
-	branchName, err := handlers.GetCurrentBranch(gitHelper)
+	switch o.Stage {
+	case StageAll, StageTracked:
+		command := commands.GitCommitAdd
+		if o.Stage == StageTracked {
+			command = commands.GitAddTracked
+		}
+		if _, err := gitHelper.ExecuteCommand(command); err != nil {
+			return nil, fmt.Errorf("failed to stage changes: %w", err)
+		}
+	case StageNone:
+	default:
+		return nil, usageError("invalid stage %q, expected all, tracked or none", o.Stage)
+	}
+
+	output, err := gitHelper.ExecuteCommand(commands.GitStagedFiles)
 	if err != nil {
-		return fmt.Errorf("failed to determine current branch: %w", err)
+		return nil, fmt.Errorf("failed to list staged files: %w", err)
+	}
+
+	files := splitLines(output)
+	if len(files) == 0 {
+		return nil, errNoChangedFiles
+	}
+
+	return files, nil
+}
+
+// selectPushRemotes asks which remotes to push to. Non-interactive runs push
+// to the remote the branch tracks, or origin, without asking.
+func (o *Options) selectPushRemotes(gitHelper helpers.GitHelper, branchName string, remotes []handlers.Remote) ([]handlers.Remote, bool) {
+	if !o.NonInteractive {
+		return handlers.SelectPushRemotes(gitHelper, branchName, remotes)
+	}
+
+	tracking := handlers.TrackingRemote(gitHelper, branchName, remotes)
+	for _, remote := range remotes {
+		if remote.Name == tracking {
+			return []handlers.Remote{remote}, true
+		}
+	}
+	return nil, false
+}
+
+// showUpstreamStatus prints the tracking branch of the branch and how far
+// ahead and behind it the branch is, fetching the upstream first when enabled
+// in the config. It returns the upstream, or nil when there is none yet.
+func (o *Options) showUpstreamStatus(gitHelper helpers.GitHelper, config *settings.Config, branchName string) *handlers.Upstream {
+	upstream := handlers.GetUpstream(gitHelper, branchName)
+	if upstream == nil {
+		fmt.Fprintln(o.out, handlers.DescribeUpstream(branchName, nil))
+		return nil
+	}
+
+	if config.Push.Fetch {
+		if err := handlers.FetchRemote(gitHelper, upstream.Remote); err != nil {
+			log.Printf("Failed to fetch, the status may be out of date: %v", err)
+		}
+	}
+
+	if err := handlers.UpdateAheadBehind(gitHelper, upstream); err != nil {
+		log.Printf("Failed to compare with the upstream: %v", err)
+		fmt.Fprintf(o.out, "Branch '%s' tracks '%s'\n", branchName, upstream.Name())
+		return upstream
+	}
+
+	fmt.Fprintln(o.out, handlers.DescribeUpstream(branchName, upstream))
+	if upstream.Diverged() {
+		fmt.Fprintln(o.out, "The branch has diverged from its upstream, e.g. after an amend or rebase. A rejected push can be force pushed with lease.")
+	}
+	return upstream
+}
+
+// recoverRejectedPushes offers to pull and retry, or force push, each push
+// that was rejected because the remote branch moved on. The results of
+// recovered pushes are replaced with the outcome of the recovery.
+func (o *Options) recoverRejectedPushes(gitHelper helpers.GitHelper, config *settings.Config, results []handlers.PushResult, branchName string, upstream *handlers.Upstream) []handlers.PushResult {
+	for i, result := range results {
+		if !handlers.IsRejectedPush(result.Err) {
+			continue
+		}
+
+		remoteBranch := branchName
+		if upstream != nil && upstream.Remote == result.Remote.Name {
+			remoteBranch = upstream.Branch
+		}
+
+		forced, err := handlers.RecoverRejectedPush(gitHelper, config, o.out, result.Remote.Name, branchName, remoteBranch)
+		if err != nil {
+			log.Printf("Push to %s not retried: %v", result.Remote.Name, err)
+			continue
+		}
+		if forced {
+			results[i].Err = nil
+			continue
+		}
+
+		fmt.Fprintf(o.out, "Retrying the push to %s\n", result.Remote.Name)
+		results[i] = handlers.PushToRemotes(gitHelper, []handlers.Remote{result.Remote}, branchName, upstream)[0]
+	}
+
+	return results
+}
+
+// stashUnselectedChanges offers to stash the changes that are not staged for
+// the commit, so they do not leak into pre-commit checks. Files with staged
+// changes are left in place. It returns a function that restores them, which
+// does nothing when nothing was stashed.
+func (o *Options) stashUnselectedChanges(gitHelper helpers.GitHelper) (restore func()) {
+	unselected, partial := handlers.UnselectedChanges(gitHelper)
+	if len(partial) > 0 && len(unselected) > 0 {
+		fmt.Fprintf(o.out, "Not stashing the unstaged changes of partly staged files, they stay in place:\n-> %s\n", strings.Join(partial, "\n-> "))
+	}
+	if len(unselected) == 0 {
+		return func() {}
+	}
+
+	if !gitHelper.ShowConfirm(fmt.Sprintf("You have %d files with changes not selected for this commit. Do you want to stash them until the commit is done?\n-> %s", len(unselected), strings.Join(unselected, "\n-> ")), false) {
+		return func() {}
 	}
 
-	if !gitHelper.ShowConfirm(fmt.Sprintf("Do you want to push the current branch '%s' to origin?", branchName), true) {
-		return fmt.Errorf("user canceled push")
+	message, err := handlers.StashUnselectedChanges(gitHelper, unselected)
+	if err != nil {
+		log.Printf("Committing without stashing: %v", err)
+		return func() {}
+	}
+
+	return func() {
+		if err := handlers.RestoreUnselectedChanges(gitHelper, message); err != nil {
+			log.Printf("Failed to restore the stashed changes: %v", err)
+		}
+	}
+}
+
+// offerBranchScreen runs before the file selection when the working tree is
+// clean. It offers the branch screen and otherwise ends the run as having
+// nothing to commit.
+func (o *Options) offerBranchScreen(gitHelper helpers.GitHelper, config *settings.Config) error {
+	choice, ok := helpers.ShowSelect("There is nothing to commit.", []helpers.SelectOption{
+		{Label: "Exit", Value: branchActionDone},
+		{Label: "Manage branches", Value: branchActionManage},
+	})
+	if ok && choice == branchActionManage {
+		return o.runBranchScreen(gitHelper, config)
+	}
+	return errNoChangedFiles
+}
+
+// listRemotes returns the configured remotes. When git does not list any, the
+// origin remote is looked up directly.
+func listRemotes(gitHelper helpers.GitHelper) ([]handlers.Remote, error) {
+	remotes, err := handlers.GetRemotes(gitHelper)
+	if err == nil && len(remotes) > 0 {
+		return remotes, nil
 	}
 
 	remoteURL, err := handlers.GetRemoteURL(gitHelper)
 	if err != nil {
-		return fmt.Errorf("failed to determine remote URL: %w", err)
+		return nil, fmt.Errorf("failed to determine remote URL: %w", err)
 	}
 
-	fmt.Printf("Pushing to %s\n", remoteURL)
-	if err = handlers.PushToOrigin(gitHelper, branchName); err != nil {
-		return fmt.Errorf("failed to push to origin: %w", err)
+	return []handlers.Remote{{Name: "origin", URL: remoteURL}}, nil
+}
+
+// splitPushResults splits the push results into the successful and failed ones.
+func splitPushResults(results []handlers.PushResult) (pushed []handlers.PushResult, failed []handlers.PushResult) {
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		} else {
+			pushed = append(pushed, result)
+		}
 	}
+	return pushed, failed
+}
 
-	fmt.Printf("Pushed to %s successfully\n", remoteURL)
+// newReferenceLookup returns the issue tracker lookup for the configured
+// tracker, or nil when no tracker is configured.
+func newReferenceLookup(gitHelper helpers.GitHelper, config *settings.Config) *handlers.ReferenceLookup {
+	return handlers.NewReferenceLookup(newTracker(gitHelper, config))
+}
 
-	return nil
+// newTracker returns the configured issue tracker, or nil when it is not
+// configured. The remote the current branch tracks is used to detect the
+// GitHub or GitLab project.
+func newTracker(gitHelper helpers.GitHelper, config *settings.Config) trackers.Tracker {
+	var remoteURL string
+	if remotes, err := listRemotes(gitHelper); err == nil {
+		branch, _ := handlers.GetCurrentBranch(gitHelper)
+		tracking := handlers.TrackingRemote(gitHelper, branch, remotes)
+		for _, remote := range remotes {
+			if remote.Name == tracking {
+				remoteURL = remote.URL
+			}
+		}
+	}
+	return trackers.New(config, remoteURL)
+}
+
+// pullRequestPrompt returns the confirmation for opening a pull request.
+func pullRequestPrompt(branchName string) string {
+	return fmt.Sprintf("Do you want to open a pull request for '%s'?", branchName)
+}
+
+// openPullRequest offers to open a pull/merge request for the pushed branch
+// and prints its URL. It is skipped when the forge of the remote is unknown.
+// Failures are logged, as the commit and push already succeeded.
+func (o *Options) openPullRequest(gitHelper helpers.GitHelper, config *settings.Config, remote handlers.Remote, branchName string) {
+	forge, err := forges.New(config, remote.URL)
+	if errors.Is(err, forges.ErrUnknownForge) {
+		log.Printf("Skipping the pull request: %v", err)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to open pull request: %v", err)
+		return
+	}
+
+	if !gitHelper.ShowConfirm(pullRequestPrompt(branchName), true) {
+		return
+	}
+
+	ctx, cancel := forges.WithTimeout(context.Background())
+	defer cancel()
+
+	pullRequest, err := handlers.OpenPullRequest(ctx, gitHelper, config, forge, remote.Name, branchName)
+	if err != nil {
+		log.Printf("Failed to open pull request: %v", err)
+		if pullRequest == nil {
+			return
+		}
+	}
+
+	if pullRequest.Existing {
+		fmt.Fprintf(o.out, "Pull request already open on %s: %s\n", forge.Name(), pullRequest.URL)
+		return
+	}
+
+	fmt.Fprintf(o.out, "Opened pull request on %s: %s\n", forge.Name(), pullRequest.URL)
 }
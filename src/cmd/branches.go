@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// Actions offered above the branches on the branch screen, and by the commit
+// wizard when there is nothing to commit.
+const (
+	branchActionCreate = "action:create"
+	branchActionDelete = "action:delete"
+	branchActionDone   = "action:done"
+	branchActionManage = "action:manage"
+)
+
+// RunBranches runs the branch management screen. It lists the local and remote
+// branches with their last commit and lets the user check out, create and
+// delete branches until they are done.
+func RunBranches(gitHelper helpers.GitHelper, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	return options.runBranchScreen(gitHelper, config)
+}
+
+// runBranchScreen shows the branch list until the user picks "Done" or
+// cancels. Failed actions are reported and the list is shown again.
+func (o *Options) runBranchScreen(gitHelper helpers.GitHelper, config *settings.Config) error {
+	for {
+		branches, err := handlers.GetBranches(gitHelper)
+		if err != nil {
+			return err
+		}
+
+		options := []helpers.SelectOption{
+			{Label: "+ Create a new branch", Value: branchActionCreate},
+			{Label: "- Delete merged branches", Value: branchActionDelete},
+			{Label: "  Done", Value: branchActionDone},
+		}
+		for _, branch := range branches {
+			options = append(options, helpers.SelectOption{Label: branch.Label(), Value: branch.Ref})
+		}
+
+		choice, ok := helpers.ShowSelect("Branches", options)
+		if !ok || choice == branchActionDone {
+			return nil
+		}
+
+		switch choice {
+		case branchActionCreate:
+			err = o.createBranch(gitHelper, branches)
+		case branchActionDelete:
+			err = o.deleteMergedBranches(gitHelper, config)
+		default:
+			err = o.switchBranch(gitHelper, branches, choice)
+		}
+
+		if err != nil {
+			fmt.Fprintln(o.out, err)
+		}
+	}
+}
+
+// switchBranch checks out the branch with the given ref.
+func (o *Options) switchBranch(gitHelper helpers.GitHelper, branches []handlers.Branch, ref string) error {
+	for _, branch := range branches {
+		if branch.Ref != ref {
+			continue
+		}
+
+		if branch.Current {
+			fmt.Fprintf(o.out, "Already on '%s'\n", branch.Name)
+			return nil
+		}
+
+		changes, err := handlers.AskUncommittedChanges(gitHelper, branch.LocalName())
+		if err != nil {
+			return err
+		}
+
+		if err := handlers.SwitchBranch(gitHelper, branch, branches, changes); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(o.out, "Switched to '%s'\n", branch.LocalName())
+		return nil
+	}
+
+	return fmt.Errorf("branch %s not found", ref)
+}
+
+// createBranch asks for the name and base of a new branch, creates it and
+// switches to it.
+func (o *Options) createBranch(gitHelper helpers.GitHelper, branches []handlers.Branch) error {
+	name, ok := helpers.ShowInput("Name of the new branch", "")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return nil
+	}
+	if err := handlers.ValidateBranchName(gitHelper, name); err != nil {
+		return err
+	}
+
+	var options []helpers.SelectOption
+	for _, branch := range branches {
+		option := helpers.SelectOption{Label: branch.Label(), Value: branch.Name}
+		if branch.Current {
+			options = append([]helpers.SelectOption{option}, options...)
+		} else {
+			options = append(options, option)
+		}
+	}
+
+	base, ok := helpers.ShowSelect(fmt.Sprintf("Create '%s' from", name), options)
+	if !ok {
+		return nil
+	}
+
+	changes, err := handlers.AskUncommittedChanges(gitHelper, name)
+	if err != nil {
+		return err
+	}
+
+	if err := handlers.CreateBranch(gitHelper, name, base, changes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.out, "Switched to a new branch '%s' from '%s'\n", name, base)
+	return nil
+}
+
+// deleteMergedBranches lets the user pick branches merged into the current
+// branch and deletes them after confirmation.
+func (o *Options) deleteMergedBranches(gitHelper helpers.GitHelper, config *settings.Config) error {
+	current, err := handlers.GetCurrentBranch(gitHelper)
+	if err != nil {
+		return err
+	}
+
+	merged, err := handlers.MergedBranches(gitHelper, config, current)
+	if err != nil {
+		return err
+	}
+	if len(merged) == 0 {
+		fmt.Fprintf(o.out, "No branches are merged into '%s'\n", current)
+		return nil
+	}
+
+	options := make([]helpers.SelectOption, len(merged))
+	for i, name := range merged {
+		options[i] = helpers.SelectOption{Label: name, Value: name}
+	}
+
+	selected, ok := helpers.ShowMultiSelect(fmt.Sprintf("Branches merged into '%s' to delete", current), options, nil)
+	if !ok || len(selected) == 0 {
+		return nil
+	}
+
+	if !gitHelper.ShowConfirm(fmt.Sprintf("Do you want to delete %d branches?\n-> %s", len(selected), strings.Join(selected, "\n-> ")), false) {
+		return nil
+	}
+
+	results := handlers.DeleteBranches(gitHelper, selected)
+	sort.Strings(selected)
+	for _, name := range selected {
+		if results[name] != nil {
+			fmt.Fprintf(o.out, "✗ %s: %v\n", name, results[name])
+		} else {
+			fmt.Fprintf(o.out, "✓ deleted %s\n", name)
+		}
+	}
+
+	return nil
+}
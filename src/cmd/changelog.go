@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// Output formats of the changelog command.
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// RunChangelog runs the changelog command. It groups the commits between the
+// refs by commit type and prints them as JSON, or as Markdown that can be
+// written to the changelog file, replacing an earlier section of the same
+// version. References are linked through the configured issue tracker. An
+// empty from starts at the last release
+// tag and an empty to means HEAD.
+func RunChangelog(gitHelper helpers.GitHelper, from string, to string, format string, opts ...Option) error {
+	options := newOptions(opts)
+
+	if format == "" {
+		format = FormatMarkdown
+	}
+	if format != FormatMarkdown && format != FormatJSON {
+		return usageError("invalid format %q, expected markdown or json", format)
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	changelog, err := handlers.BuildChangelog(gitHelper, config, newTracker(gitHelper, config), from, to)
+	if err != nil {
+		return err
+	}
+
+	if format == FormatJSON {
+		data, err := changelog.JSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode changelog: %w", err)
+		}
+		fmt.Fprintln(options.out, string(data))
+		return nil
+	}
+
+	markdown := changelog.Markdown()
+	fmt.Fprintln(options.out, markdown)
+
+	file := handlers.ChangelogFile(config)
+	if !gitHelper.ShowConfirm(fmt.Sprintf("Do you want to add this to %s?", file), true) {
+		return nil
+	}
+
+	if err := handlers.WriteChangelog(file, markdown); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(options.out, "Updated %s\n", file)
+
+	return nil
+}
@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// AppName is the name of the binary used in help text.
+const AppName = "git-commit-ui"
+
+// Version is the version of the binary. It is set at build time with
+// -ldflags "-X github.com/kurianvarkey/gitcommitui/src/cmd.Version=v1.2.3" and
+// falls back to the module version from the build info.
+var Version = ""
+
+// commitFlagNames are the flags that make a commit non-interactive.
+var commitFlagNames = []string{"type", "version", "ref", "summary", "body", "stage", "push", "yes"}
+
+// Command is a node of the command tree. A command without Run only groups
+// its subcommands, e.g. "config".
+type Command struct {
+	Name        string
+	Args        string // arguments shown in the usage, e.g. "[from] [to]"
+	Summary     string
+	Interactive bool // shows forms, so the start-up spinner is shown first
+	Flags       func(flags *flag.FlagSet, values *flagValues)
+	Run         func(gitHelper helpers.GitHelper, values *flagValues, args []string) error
+	Commands    []*Command
+}
+
+// flagValues holds the values of every flag. Flags given before the command
+// name are kept as the defaults of the command's flags, so the commit flags
+// still work before a mode as in earlier versions.
+type flagValues struct {
+	repo, config, output string
+	verbose, noColor     bool
+
+	sign, signKey string
+	signOff       bool
+
+	commitType, version, ref, summary, body, stage string
+	push, yes                                      bool
+
+	format      string
+	messageFile string
+	force       bool
+
+	set    map[string]bool // names of the flags given on the command line
+	events *eventWriter    // set with -output json
+	out    io.Writer       // text output, stderr with -output json
+}
+
+// Execute parses the command line arguments, without the program name, and
+// runs the command they name. Without a command the commit wizard runs.
+func Execute(gitHelper helpers.GitHelper, args []string) error {
+	root := commandTree()
+	values := &flagValues{set: map[string]bool{}, out: os.Stdout}
+
+	command, path := root, []string{}
+	for {
+		flags := newFlagSet(command, path, values)
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil
+			}
+			return withKind(ErrUsage, err)
+		}
+		flags.Visit(func(f *flag.Flag) { values.set[f.Name] = true })
+		args = flags.Args()
+
+		child := command.find(firstArg(args))
+		if child == nil {
+			break
+		}
+		command, path, args = child, append(path, child.Name), args[1:]
+	}
+
+	if err := values.applyGlobal(); err != nil {
+		return err
+	}
+
+	if len(command.Commands) > 0 && len(args) > 0 {
+		return usageError("unknown command %q, run '%s help' for the commands", strings.Join(append(path, args[0]), " "), AppName)
+	}
+	if command.Run == nil {
+		printUsage(values.out, command, path)
+		return usageError("missing subcommand for %q", strings.Join(path, " "))
+	}
+	if command.Args == "" && len(args) > 0 {
+		return usageError("unexpected arguments for %q: %s", strings.Join(append([]string{AppName}, path...), " "), strings.Join(args, " "))
+	}
+
+	nonInteractive := values.nonInteractive()
+	if nonInteractive {
+		helpers.DisablePrompts(values.yes)
+	} else if command.Interactive {
+		helpers.ShowSpinner("Initialising...", func() {
+			time.Sleep(1 * time.Second)
+		})
+	}
+
+	err := command.Run(gitHelper, values, args)
+	if err != nil {
+		values.events.emitError(err)
+	}
+	return err
+}
+
+// commandTree returns the root command, which commits, and its subcommands.
+func commandTree() *Command {
+	root := &Command{
+		Name:        AppName,
+		Summary:     "Commit the changes through the commit form, or run one of the commands.",
+		Interactive: true,
+		Flags:       commitFlags,
+		Run:         runCommit,
+	}
+
+	root.Commands = []*Command{
+		{Name: "commit", Summary: "Commit the changes through the commit form (the default command)", Interactive: true, Flags: commitFlags, Run: runCommit},
+		{Name: "amend", Summary: "Amend the last commit", Interactive: true, Flags: signingFlags, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunAmend(gitHelper, &handlers.DefaultCommitForm{}, values.commitOptions()...)
+		}},
+		{Name: handlers.FixupCommit, Summary: "Create a fixup commit for a picked commit", Interactive: true, Flags: signingFlags, Run: runFixupCommand(handlers.FixupCommit)},
+		{Name: handlers.SquashCommit, Summary: "Create a squash commit for a picked commit", Interactive: true, Flags: signingFlags, Run: runFixupCommand(handlers.SquashCommit)},
+		{Name: "push", Summary: "Push the current branch to its remotes", Interactive: true, Flags: yesFlag, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunPush(gitHelper, values.pushOptions()...)
+		}},
+		{Name: "lint", Args: "[range]", Summary: "Check commit messages against the commit format", Flags: lintFlags, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunLint(gitHelper, firstArg(args), values.messageFile, values.withOutput())
+		}},
+		{Name: "branches", Summary: "Switch, create and delete branches", Interactive: true, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunBranches(gitHelper, values.withOutput())
+		}},
+		{Name: "stash", Summary: "Show, apply, pop and drop stashes", Interactive: true, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunStash(gitHelper, values.withOutput())
+		}},
+		{Name: "log", Summary: "Browse and filter the commit history", Interactive: true, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunLog(gitHelper, values.withOutput())
+		}},
+		{Name: "release", Summary: "Tag the next semantic version", Interactive: true, Flags: yesFlag, Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunRelease(gitHelper, values.withOutput())
+		}},
+		{Name: "changelog", Args: "[from] [to]", Summary: "Write the changes between two refs to the changelog", Interactive: true, Flags: formatFlag("markdown or json"), Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunChangelog(gitHelper, argAt(args, 0), argAt(args, 1), values.format, values.withOutput())
+		}},
+		{Name: "report", Args: "[from] [to]", Summary: "Group the commits between two refs by reference", Flags: formatFlag("markdown, csv or json"), Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunReport(gitHelper, argAt(args, 0), argAt(args, 1), values.format, values.withOutput())
+		}},
+		{Name: "stats", Args: "[range]", Summary: "Show commit statistics", Interactive: true, Flags: formatFlag("chart or json"), Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			return RunStats(gitHelper, firstArg(args), values.format, values.withOutput())
+		}},
+		{Name: "config", Summary: "Manage the config file", Commands: []*Command{
+			{Name: "init", Summary: "Write the default config file", Flags: forceFlag("replace an existing config file"), Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+				return RunConfigInit(values.force, values.withOutput())
+			}},
+			{Name: "validate", Summary: "Check the config file for unknown keys and invalid values", Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+				return RunConfigValidate(values.withOutput())
+			}},
+		}},
+		{Name: "hooks", Summary: "Manage git hooks", Commands: []*Command{
+			{Name: "install", Summary: "Install a commit-msg hook that lints every commit message", Flags: forceFlag("replace an existing commit-msg hook"), Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+				return RunHooksInstall(gitHelper, values.force, values.withOutput())
+			}},
+		}},
+		{Name: "version", Summary: "Print the version", Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			fmt.Fprintf(values.out, "%s %s\n", AppName, appVersion())
+			return nil
+		}},
+		{Name: "help", Args: "[command]", Summary: "Show help for a command", Run: func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+			command, path := root, []string{}
+			for _, name := range args {
+				child := command.find(name)
+				if child == nil {
+					return usageError("unknown command %q", strings.Join(append(path, name), " "))
+				}
+				command, path = child, append(path, name)
+			}
+			printUsage(values.out, command, path)
+			return nil
+		}},
+	}
+
+	return root
+}
+
+// runCommit runs the commit wizard, or commits without prompts when any of the
+// commit flags is given.
+func runCommit(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+	var form handlers.CommitForm = &handlers.DefaultCommitForm{}
+	if values.nonInteractive() {
+		form = &handlers.StaticCommitForm{Version: values.version, CommitType: values.commitType, Jira: values.ref, Summary: values.summary, Body: values.body}
+	}
+
+	return RunApp(gitHelper, form, values.commitOptions()...)
+}
+
+// runFixupCommand returns the run function of the fixup or squash command.
+func runFixupCommand(kind string) func(helpers.GitHelper, *flagValues, []string) error {
+	return func(gitHelper helpers.GitHelper, values *flagValues, args []string) error {
+		return RunFixup(gitHelper, kind, values.commitOptions()...)
+	}
+}
+
+// globalFlags registers the flags accepted by every command.
+func globalFlags(flags *flag.FlagSet, values *flagValues) {
+	flags.StringVar(&values.repo, "C", values.repo, "run in the repository at this path")
+	flags.StringVar(&values.config, "config", values.config, "path of the config file (default "+settings.DefaultConfigFile+")")
+	flags.BoolVar(&values.verbose, "v", values.verbose, "print the git commands as they run")
+	flags.BoolVar(&values.noColor, "no-color", values.noColor, "disable colors")
+	flags.StringVar(&values.output, "output", values.output, "output format: text (default) or json for newline-delimited JSON events on stdout")
+}
+
+// signingFlags registers the flags that sign commits.
+func signingFlags(flags *flag.FlagSet, values *flagValues) {
+	flags.StringVar(&values.sign, "sign", values.sign, "sign the commit: always, never or auto (default from config)")
+	flags.StringVar(&values.signKey, "sign-key", values.signKey, "key used to sign the commit (default from config or user.signingkey)")
+	flags.BoolVar(&values.signOff, "signoff", values.signOff, "add a Signed-off-by trailer to the commit")
+}
+
+// commitFlags registers the signing flags and the flags of a non-interactive
+// commit.
+func commitFlags(flags *flag.FlagSet, values *flagValues) {
+	signingFlags(flags, values)
+	flags.StringVar(&values.commitType, "type", values.commitType, "commit type; any commit flag commits without prompting")
+	flags.StringVar(&values.version, "version", values.version, "version of the commit")
+	flags.StringVar(&values.ref, "ref", values.ref, "reference of the commit, e.g. a Jira key")
+	flags.StringVar(&values.summary, "summary", values.summary, "summary of the commit")
+	flags.StringVar(&values.body, "body", values.body, "body added below the summary")
+	flags.StringVar(&values.stage, "stage", values.stage, "what to stage before committing: all, tracked or none")
+	flags.BoolVar(&values.push, "push", values.push, "push the commit to the tracking remote")
+	yesFlag(flags, values)
+}
+
+// yesFlag registers the flag that answers yes to every confirmation.
+func yesFlag(flags *flag.FlagSet, values *flagValues) {
+	flags.BoolVar(&values.yes, "yes", values.yes, "answer yes to every confirmation without prompting")
+}
+
+// lintFlags registers the flags of the lint command.
+func lintFlags(flags *flag.FlagSet, values *flagValues) {
+	flags.StringVar(&values.messageFile, "message-file", values.messageFile, "lint the message in this file, as given to the commit-msg hook")
+}
+
+// formatFlag returns a function registering the output format flag with the
+// given choices.
+func formatFlag(choices string) func(*flag.FlagSet, *flagValues) {
+	return func(flags *flag.FlagSet, values *flagValues) {
+		flags.StringVar(&values.format, "format", values.format, "output format: "+choices)
+	}
+}
+
+// forceFlag returns a function registering the force flag.
+func forceFlag(usage string) func(*flag.FlagSet, *flagValues) {
+	return func(flags *flag.FlagSet, values *flagValues) {
+		flags.BoolVar(&values.force, "force", values.force, usage)
+	}
+}
+
+// newFlagSet returns the flag set of the command with the global flags, which
+// prints the command's help for -h.
+func newFlagSet(command *Command, path []string, values *flagValues) *flag.FlagSet {
+	flags := flag.NewFlagSet(strings.Join(append([]string{AppName}, path...), " "), flag.ContinueOnError)
+	globalFlags(flags, values)
+	if command.Flags != nil {
+		command.Flags(flags, values)
+	}
+	flags.Usage = func() { printUsage(flags.Output(), command, path) }
+	return flags
+}
+
+// printUsage prints the help of the command: its usage, subcommands, flags and
+// the global flags.
+func printUsage(w io.Writer, command *Command, path []string) {
+	name := strings.Join(append([]string{AppName}, path...), " ")
+	usage := name + " [flags]"
+	if len(command.Commands) > 0 {
+		usage += " <command>"
+	}
+	if command.Args != "" {
+		usage += " " + command.Args
+	}
+
+	fmt.Fprintf(w, "Usage: %s\n\n%s\n", usage, command.Summary)
+
+	if len(command.Commands) > 0 {
+		fmt.Fprintf(w, "\nCommands:\n")
+		for _, child := range command.Commands {
+			fmt.Fprintf(w, "  %-10s %s\n", child.Name, child.Summary)
+		}
+	}
+
+	if command.Flags != nil {
+		flags := flag.NewFlagSet(name, flag.ContinueOnError)
+		command.Flags(flags, &flagValues{})
+		flags.SetOutput(w)
+		fmt.Fprintf(w, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	globalFlags(flags, &flagValues{})
+	flags.SetOutput(w)
+	fmt.Fprintf(w, "\nGlobal flags:\n")
+	flags.PrintDefaults()
+
+	if len(command.Commands) > 0 {
+		fmt.Fprintf(w, "\nRun '%s <command>' for more about a command.\n", strings.Join(append([]string{AppName, "help"}, path...), " "))
+	}
+}
+
+// find returns the subcommand with the name, or nil.
+func (c *Command) find(name string) *Command {
+	for _, child := range c.Commands {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// applyGlobal applies the global flags: the repository path, config file,
+// verbosity, colors and output. With the JSON output, stdout only carries the
+// events and the text output of the commands goes to stderr.
+func (v *flagValues) applyGlobal() error {
+	switch v.output {
+	case "", OutputText:
+	case OutputJSON:
+		v.events = newEventWriter(os.Stdout)
+		v.out = os.Stderr
+	default:
+		return usageError("invalid output %q, expected text or json", v.output)
+	}
+
+	if v.repo != "" {
+		if err := os.Chdir(v.repo); err != nil {
+			return usageError("failed to open the repository: %w", err)
+		}
+	}
+	if v.config != "" {
+		settings.SetConfigFile(v.config)
+	}
+	helpers.SetVerbose(v.verbose)
+	if v.noColor {
+		settings.DisableColor()
+	}
+	return nil
+}
+
+// nonInteractive reports whether any of the commit flags was given. Runs with
+// the JSON output are scripted as well, and prompts would draw on stdout.
+func (v *flagValues) nonInteractive() bool {
+	if v.output == OutputJSON {
+		return true
+	}
+	for _, name := range commitFlagNames {
+		if v.set[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// commitOptions returns the options of the commit, amend, fixup and squash
+// commands.
+func (v *flagValues) commitOptions() []Option {
+	opts := []Option{WithSigning(v.sign, v.signKey), WithSignOff(v.signOff), v.withOutput()}
+	if v.nonInteractive() {
+		opts = append(opts, WithNonInteractive(v.stage, v.push))
+	}
+	return opts
+}
+
+// pushOptions returns the options of the push command, which pushes to the
+// tracking remote without asking when -yes is given.
+func (v *flagValues) pushOptions() []Option {
+	opts := []Option{v.withOutput()}
+	if v.yes {
+		opts = append(opts, WithNonInteractive("", true))
+	}
+	return opts
+}
+
+// withOutput passes the event writer of -output json and the text output on
+// to the command.
+func (v *flagValues) withOutput() Option {
+	return func(o *Options) {
+		o.events = v.events
+		o.out = v.out
+	}
+}
+
+// appVersion returns the version of the binary.
+func appVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// firstArg returns the first argument, or an empty string.
+func firstArg(args []string) string {
+	return argAt(args, 0)
+}
+
+// argAt returns the argument at the index, or an empty string.
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
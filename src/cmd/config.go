@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// RunConfigInit runs the config init command. It writes the default config
+// file, replacing an existing one only when force is set.
+func RunConfigInit(force bool, opts ...Option) error {
+	options := newOptions(opts)
+
+	if err := settings.InitConfig(force); err != nil {
+		return withKind(ErrConfig, err)
+	}
+
+	fmt.Fprintf(options.out, "Created %s\n", settings.ConfigFile())
+	return nil
+}
+
+// RunConfigValidate runs the config validate command. It prints every problem
+// found in the config file and returns an error when there is any.
+func RunConfigValidate(opts ...Option) error {
+	options := newOptions(opts)
+
+	problems, err := settings.ValidateConfigFile()
+	if err != nil {
+		return withKind(ErrConfig, err)
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintf(options.out, "✗ %s\n", problem)
+	}
+	if len(problems) > 0 {
+		return withKind(ErrConfig, fmt.Errorf("%s has %d problems", settings.ConfigFile(), len(problems)))
+	}
+
+	fmt.Fprintf(options.out, "✓ %s is valid\n", settings.ConfigFile())
+	return nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// Output formats of the -output flag.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Event names, one per stage of the commit.
+const (
+	EventRepoDetected    = "repo_detected"
+	EventFilesFound      = "files_found"
+	EventFilesStaged     = "files_staged"
+	EventMessageComposed = "message_composed"
+	EventCommitted       = "committed"
+	EventPushResult      = "push_result"
+	EventError           = "error"
+)
+
+// Error codes of error events.
+const (
+	CodeError           = "error"
+	CodeNoChanges       = "no_changes"
+	CodeProtectedBranch = "protected_branch"
+	CodeCanceled        = "canceled"
+	CodeConfig          = "config"
+	CodeUsage           = "usage"
+	CodeCommitFailed    = "commit_failed"
+	CodePushFailed      = "push_failed"
+	CodeHookExists      = "hook_exists"
+)
+
+// Event is a machine-readable progress event, written as one line of JSON.
+// Only the fields of the stage are set.
+type Event struct {
+	Event   string    `json:"event"`
+	Time    time.Time `json:"time"`
+	Root    string    `json:"root,omitempty"`
+	Branch  string    `json:"branch,omitempty"`
+	Files   []string  `json:"files,omitempty"`
+	Message string    `json:"message,omitempty"`
+	SHA     string    `json:"sha,omitempty"`
+	Remote  string    `json:"remote,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	OK      *bool     `json:"ok,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Code    string    `json:"code,omitempty"`
+}
+
+// errCommitFailed is returned with the JSON output when HEAD did not move
+// after the commit.
+var errCommitFailed = withKind(ErrCommitFailed, errors.New("the commit failed"))
+
+// eventWriter writes events as newline-delimited JSON. A nil writer writes
+// nothing, so the text output needs no checks.
+type eventWriter struct {
+	encoder *json.Encoder
+}
+
+// newEventWriter returns a writer of events to w.
+func newEventWriter(w io.Writer) *eventWriter {
+	return &eventWriter{encoder: json.NewEncoder(w)}
+}
+
+// WithEvents writes the progress events of the commit to w as
+// newline-delimited JSON.
+func WithEvents(w io.Writer) Option {
+	return func(o *Options) {
+		o.events = newEventWriter(w)
+	}
+}
+
+// emit writes the event, stamped with the current time.
+func (w *eventWriter) emit(event Event) {
+	if w == nil {
+		return
+	}
+
+	event.Time = time.Now().UTC()
+	_ = w.encoder.Encode(event)
+}
+
+// emitError writes an error event with the code of the error.
+func (w *eventWriter) emitError(err error) {
+	w.emit(Event{Event: EventError, Error: err.Error(), Code: ErrorCode(err)})
+}
+
+// ErrorCode returns the code of the error for error events. It follows the
+// exit code of the error, with its own codes for protected branches and
+// existing hooks.
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, handlers.ErrProtectedBranch):
+		return CodeProtectedBranch
+	case errors.Is(err, handlers.ErrHookExists):
+		return CodeHookExists
+	}
+
+	switch ExitCode(err) {
+	case ExitCanceled:
+		return CodeCanceled
+	case ExitNothingToCommit:
+		return CodeNoChanges
+	case ExitConfig:
+		return CodeConfig
+	case ExitCommitFailed:
+		return CodeCommitFailed
+	case ExitPushFailed:
+		return CodePushFailed
+	case ExitUsage:
+		return CodeUsage
+	}
+	return CodeError
+}
+
+// emitRepoDetected writes the repository root and the current branch.
+func (w *eventWriter) emitRepoDetected(gitHelper helpers.GitHelper) {
+	if w == nil {
+		return
+	}
+
+	root, _ := gitHelper.ExecuteCommand(commands.GitTopLevel)
+	branch, _ := handlers.GetCurrentBranch(gitHelper)
+	w.emit(Event{Event: EventRepoDetected, Root: strings.TrimSpace(root), Branch: branch})
+}
+
+// emitStagedFiles writes the files staged for the commit.
+func (w *eventWriter) emitStagedFiles(gitHelper helpers.GitHelper) {
+	if w == nil {
+		return
+	}
+
+	output, _ := gitHelper.ExecuteCommand(commands.GitStagedFiles)
+	w.emit(Event{Event: EventFilesStaged, Files: splitLines(output)})
+}
+
+// headCommit returns the SHA of HEAD, or an empty string before the first
+// commit or without events, as it is only needed for them.
+func (w *eventWriter) headCommit(gitHelper helpers.GitHelper) string {
+	if w == nil {
+		return ""
+	}
+
+	output, err := gitHelper.ExecuteCommand(fmt.Sprintf(commands.GitRevParse, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// emitCommitted writes the new commit SHA. It returns errCommitFailed when
+// HEAD is still at the commit from before.
+func (w *eventWriter) emitCommitted(gitHelper helpers.GitHelper, previous string) error {
+	if w == nil {
+		return nil
+	}
+
+	sha := w.headCommit(gitHelper)
+	if sha == "" || sha == previous {
+		return errCommitFailed
+	}
+
+	w.emit(Event{Event: EventCommitted, SHA: sha})
+	return nil
+}
+
+// emitPushResults writes the outcome of the push to each remote.
+func (w *eventWriter) emitPushResults(results []handlers.PushResult) {
+	for _, result := range results {
+		ok := result.Err == nil
+		event := Event{Event: EventPushResult, Remote: result.Remote.Name, URL: result.Remote.URL, OK: &ok}
+		if result.Err != nil {
+			event.Error = result.Err.Error()
+		}
+		w.emit(event)
+	}
+}
+
+// splitLines returns the non-empty lines of the output.
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
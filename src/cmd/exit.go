@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// Exit codes returned by the application, so scripts and hooks can tell why a
+// run failed.
+const (
+	ExitOK              = 0
+	ExitInternal        = 1
+	ExitCanceled        = 2
+	ExitNothingToCommit = 3
+	ExitConfig          = 4
+	ExitCommitFailed    = 5
+	ExitPushFailed      = 6
+	ExitUsage           = 7
+)
+
+// Kinds of errors returned by the commands. The errors returned wrap one of
+// them, which ExitCode maps onto an exit code.
+var (
+	ErrCanceled        = errors.New("canceled")
+	ErrNothingToCommit = errors.New("nothing to commit")
+	ErrConfig          = errors.New("invalid config")
+	ErrCommitFailed    = errors.New("commit failed")
+	ErrPushFailed      = errors.New("push failed")
+	ErrUsage           = errors.New("invalid usage")
+)
+
+// kindError marks an error with its kind while keeping its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// withKind returns err marked with the kind, or nil when err is nil.
+func withKind(kind error, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// canceled returns the error for a step the user canceled.
+func canceled(step string) error {
+	return withKind(ErrCanceled, fmt.Errorf("user canceled %s", step))
+}
+
+// loadConfig loads the config, marking failures as config errors.
+func loadConfig() (*settings.Config, error) {
+	config, err := settings.LoadConfig()
+	if err != nil {
+		return nil, withKind(ErrConfig, fmt.Errorf("error loading config: %w", err))
+	}
+	return config, nil
+}
+
+// usageError returns an error for invalid commands, flags or flag values.
+func usageError(format string, args ...any) error {
+	return withKind(ErrUsage, fmt.Errorf(format, args...))
+}
+
+// checkRepository returns an error when the working directory is not a git
+// repository and none was initialised. Declining to initialise one counts as
+// canceling.
+func checkRepository(gitHelper helpers.GitHelper) error {
+	err := handlers.CheckForGitInitialise(gitHelper)
+	if errors.Is(err, handlers.ErrNotGitRepository) {
+		return withKind(ErrCanceled, err)
+	}
+	return err
+}
+
+// ExitCode returns the exit code for an error returned by Execute. Errors of
+// no known kind are internal errors.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrUsage), errors.Is(err, helpers.ErrConfirmationRequired):
+		return ExitUsage
+	case errors.Is(err, ErrCanceled), errors.Is(err, handlers.ErrCommitCanceled), errors.Is(err, handlers.ErrForcePushCanceled),
+		errors.Is(err, handlers.ErrSwitchCanceled), errors.Is(err, handlers.ErrRecoveryAborted):
+		return ExitCanceled
+	case errors.Is(err, ErrNothingToCommit):
+		return ExitNothingToCommit
+	case errors.Is(err, ErrConfig):
+		return ExitConfig
+	case errors.Is(err, ErrCommitFailed):
+		return ExitCommitFailed
+	case errors.Is(err, ErrPushFailed), errors.Is(err, handlers.ErrProtectedBranch):
+		return ExitPushFailed
+	}
+	return ExitInternal
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// fixupCommitLimit is the number of recent commits offered in the picker.
+const fixupCommitLimit = 50
+
+// RunFixup runs the fixup or squash mode. It lets the user pick one of the
+// commits on the branch not yet pushed to its upstream, or one of the recent
+// commits when there is no upstream, commits the staged changes as a 'fixup!' or
+// 'squash!' commit for it and optionally folds it in with an autosquash rebase.
+// It refuses to run on a protected branch, as the commits would land on it
+// directly, and asks again before an autosquash rebase rewrites pushed
+// commits.
+func RunFixup(gitHelper helpers.GitHelper, kind string, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	if err := options.applySigning(gitHelper, config); err != nil {
+		return err
+	}
+
+	if branch, err := handlers.GetCurrentBranch(gitHelper); err == nil && handlers.IsProtectedBranch(config, branch) {
+		return fmt.Errorf("'%s' is a protected branch, %s commits cannot be made on it directly", branch, kind)
+	}
+
+	if _, err := collectChangedFiles(gitHelper); err != nil {
+		return err
+	}
+
+	revisionRange := ""
+	if _, err := gitHelper.ExecuteCommand(fmt.Sprintf(commands.GitRevParse, "@{upstream}")); err == nil {
+		revisionRange = "@{upstream}..HEAD"
+	}
+
+	commits, err := handlers.GetCommits(gitHelper, config, revisionRange, fixupCommitLimit)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		if revisionRange != "" {
+			return fmt.Errorf("no commits to %s that are not pushed yet", kind)
+		}
+		return fmt.Errorf("no commits to %s", kind)
+	}
+
+	target, ok := handlers.PickCommit(fmt.Sprintf("Select the commit to %s", kind), commits)
+	if !ok {
+		return canceled(kind)
+	}
+
+	if err := handlers.CreateFixupCommit(gitHelper, kind, target.Hash, config.Signing); err != nil {
+		return withKind(ErrCommitFailed, fmt.Errorf("failed to create %s commit: %w", kind, err))
+	}
+
+	fmt.Fprintf(options.out, "Created %s commit for %s\n", kind, target.Label())
+
+	base, pushed := handlers.AutosquashBase(gitHelper, target)
+	title := fmt.Sprintf("Do you want to run an autosquash rebase onto '%s' now?", base)
+	if pushed {
+		title = fmt.Sprintf("%s is already pushed, so an autosquash rebase onto '%s' rewrites pushed commits and the branch has to be force pushed. Do you want to run it anyway?", target.ShortHash, base)
+	}
+	if !gitHelper.ShowConfirm(title, false) {
+		return nil
+	}
+
+	if err := handlers.AutosquashRebase(gitHelper, base); err != nil {
+		return withKind(ErrCommitFailed, fmt.Errorf("failed to run autosquash rebase: %w", err))
+	}
+
+	fmt.Fprintln(options.out, "Autosquash rebase completed successfully")
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// RunHooksInstall runs the hooks install command. It installs a commit-msg
+// hook that lints every commit message with this executable.
+func RunHooksInstall(gitHelper helpers.GitHelper, force bool, opts ...Option) error {
+	options := newOptions(opts)
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the executable: %w", err)
+	}
+
+	hook, err := handlers.InstallCommitMsgHook(gitHelper, executable, force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(options.out, "Installed the commit-msg hook at %s\n", strings.TrimPrefix(hook, "./"))
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// lintLimit is the most commits linted in one run.
+const lintLimit = 1000
+
+// RunLint runs the lint command. It checks a message file, as given to the
+// commit-msg hook, or the commit messages in the revision range against the
+// commit format. Without either, the commits not yet pushed to the upstream
+// are checked, or the last commit when the branch has no upstream. It returns
+// an error when any message has problems.
+func RunLint(gitHelper helpers.GitHelper, revisionRange string, messageFile string, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if messageFile != "" {
+		data, err := os.ReadFile(messageFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", messageFile, err)
+		}
+
+		problems := handlers.LintMessage(config, string(data))
+		for _, problem := range problems {
+			fmt.Fprintf(options.out, "✗ %s\n", problem)
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("the commit message does not follow the conventions")
+		}
+		return nil
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	limit := lintLimit
+	if revisionRange == "" {
+		revisionRange, limit = unpushedRange(gitHelper)
+	}
+
+	results, total, err := handlers.LintCommits(gitHelper, config, revisionRange, limit)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(options.out, "✗ %s %s\n", result.Commit.ShortHash, result.Commit.Subject())
+		for _, problem := range result.Problems {
+			fmt.Fprintf(options.out, "    %s\n", problem)
+		}
+	}
+
+	if len(results) > 0 {
+		return fmt.Errorf("%d of %d commit messages do not follow the conventions", len(results), total)
+	}
+
+	fmt.Fprintf(options.out, "✓ %d commit messages follow the conventions\n", total)
+	return nil
+}
+
+// unpushedRange returns the range of the commits not on the upstream yet, or
+// the last commit when the branch has no upstream.
+func unpushedRange(gitHelper helpers.GitHelper) (string, int) {
+	if _, err := gitHelper.ExecuteCommand(fmt.Sprintf(commands.GitRevParse, "@{upstream}")); err == nil {
+		return "@{upstream}..HEAD", lintLimit
+	}
+	return "HEAD", 1
+}
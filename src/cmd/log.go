@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// logLimit is the number of commits read into the log browser.
+const logLimit = 500
+
+// Actions offered on the log screens.
+const (
+	logActionFilter      = "action:filter"
+	logActionClear       = "action:clear"
+	logActionDone        = "action:done"
+	logActionCopySHA     = "copy:sha"
+	logActionCopyMessage = "copy:message"
+	logActionBack        = "back"
+
+	logFieldType      = "field:type"
+	logFieldReference = "field:reference"
+	logFieldVersion   = "field:version"
+	logFieldAuthor    = "field:author"
+	logFieldSince     = "field:since"
+	logFieldUntil     = "field:until"
+	logFieldText      = "field:text"
+)
+
+// nonConformingFlag marks commits whose message does not match the commit
+// format in the log list.
+const nonConformingFlag = "⚠ "
+
+// RunLog runs the log browser. It lists the recent commits, lets the user
+// filter them by type, reference, version, author, date range and text, and
+// shows the details of a picked commit with the option to copy its SHA or
+// message to the clipboard.
+func RunLog(gitHelper helpers.GitHelper, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	commits, err := handlers.GetCommits(gitHelper, config, "", logLimit)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		fmt.Fprintln(options.out, "There are no commits")
+		return nil
+	}
+
+	var filter handlers.LogFilter
+	for {
+		filtered := handlers.FilterCommits(commits, filter)
+
+		choices := []helpers.SelectOption{
+			{Label: fmt.Sprintf("  Filter (%s)", filter.Describe()), Value: logActionFilter},
+		}
+		if !filter.IsEmpty() {
+			choices = append(choices, helpers.SelectOption{Label: "  Clear filters", Value: logActionClear})
+		}
+		choices = append(choices, helpers.SelectOption{Label: "  Done", Value: logActionDone})
+		for _, commit := range filtered {
+			choices = append(choices, helpers.SelectOption{Label: logLabel(commit), Value: commit.Hash})
+		}
+
+		title := fmt.Sprintf("Commits (%d of %d)", len(filtered), len(commits))
+		choice, ok := helpers.ShowSelect(title, choices)
+		if !ok || choice == logActionDone {
+			return nil
+		}
+
+		switch choice {
+		case logActionFilter:
+			filter = options.editLogFilter(config, commits, filter)
+		case logActionClear:
+			filter = handlers.LogFilter{}
+		default:
+			for _, commit := range filtered {
+				if commit.Hash == choice {
+					options.showLogCommit(gitHelper, commit)
+					break
+				}
+			}
+		}
+	}
+}
+
+// logLabel returns the list label of the commit, flagging commits that do not
+// match the commit format.
+func logLabel(commit handlers.CommitInfo) string {
+	if !commit.Conforming {
+		return nonConformingFlag + commit.Label()
+	}
+	return "  " + commit.Label()
+}
+
+// editLogFilter lets the user change the filter fields one at a time until
+// they go back to the list.
+func (o *Options) editLogFilter(config *settings.Config, commits []handlers.CommitInfo, filter handlers.LogFilter) handlers.LogFilter {
+	for {
+		field, ok := helpers.ShowSelect("Filter commits", []helpers.SelectOption{
+			{Label: "Type: " + orAny(filter.Type), Value: logFieldType},
+			{Label: "Reference: " + orAny(filter.Reference), Value: logFieldReference},
+			{Label: "Version: " + orAny(filter.Version), Value: logFieldVersion},
+			{Label: "Author: " + orAny(filter.Author), Value: logFieldAuthor},
+			{Label: "Since: " + orAny(formatFilterDate(filter, true)), Value: logFieldSince},
+			{Label: "Until: " + orAny(formatFilterDate(filter, false)), Value: logFieldUntil},
+			{Label: "Text: " + orAny(filter.Text), Value: logFieldText},
+			{Label: "Back", Value: logActionBack},
+		})
+		if !ok || field == logActionBack {
+			return filter
+		}
+
+		switch field {
+		case logFieldType:
+			filter.Type = selectFilterValue("Type", config.CommitTypes, filter.Type)
+		case logFieldReference:
+			filter.Reference = inputFilterValue("Reference", filter.Reference)
+		case logFieldVersion:
+			filter.Version = inputFilterValue("Version", filter.Version)
+		case logFieldAuthor:
+			filter.Author = selectFilterValue("Author", commitAuthors(commits), filter.Author)
+		case logFieldSince:
+			filter.Since = o.inputFilterDate("Since (YYYY-MM-DD)", formatFilterDate(filter, true), filter.Since)
+		case logFieldUntil:
+			filter.Until = o.inputFilterDate("Until (YYYY-MM-DD)", formatFilterDate(filter, false), filter.Until)
+		case logFieldText:
+			filter.Text = inputFilterValue("Search the messages", filter.Text)
+		}
+	}
+}
+
+// selectFilterValue asks the user to pick one of the values, or any. The
+// current value is kept when the prompt is canceled.
+func selectFilterValue(title string, values []string, current string) string {
+	options := []helpers.SelectOption{{Label: "Any", Value: ""}}
+	for _, value := range values {
+		options = append(options, helpers.SelectOption{Label: value, Value: value})
+	}
+
+	value, ok := helpers.ShowSelect(title, options)
+	if !ok {
+		return current
+	}
+	return value
+}
+
+// inputFilterValue asks the user for a value. The current value is kept when
+// the prompt is canceled.
+func inputFilterValue(title string, current string) string {
+	value, ok := helpers.ShowInput(title, current)
+	if !ok {
+		return current
+	}
+	return value
+}
+
+// inputFilterDate asks the user for a date, keeping the current date when the
+// prompt is canceled or the date is invalid.
+func (o *Options) inputFilterDate(title string, value string, current time.Time) time.Time {
+	value, ok := helpers.ShowInput(title, value)
+	if !ok {
+		return current
+	}
+
+	date, err := handlers.ParseFilterDate(value)
+	if err != nil {
+		fmt.Fprintln(o.out, err)
+		return current
+	}
+	return date
+}
+
+// formatFilterDate returns the since or until date of the filter, or an empty
+// string when it is not set.
+func formatFilterDate(filter handlers.LogFilter, since bool) string {
+	date := filter.Until
+	if since {
+		date = filter.Since
+	}
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.DateOnly)
+}
+
+// orAny returns the value, or "any" when it is empty.
+func orAny(value string) string {
+	if value == "" {
+		return "any"
+	}
+	return value
+}
+
+// commitAuthors returns the sorted names of the commit authors.
+func commitAuthors(commits []handlers.CommitInfo) []string {
+	seen := map[string]bool{}
+	var authors []string
+	for _, commit := range commits {
+		if commit.Author != "" && !seen[commit.Author] {
+			seen[commit.Author] = true
+			authors = append(authors, commit.Author)
+		}
+	}
+	sort.Strings(authors)
+	return authors
+}
+
+// showLogCommit prints the commit with its diff and lets the user copy its
+// SHA or message to the clipboard.
+func (o *Options) showLogCommit(gitHelper helpers.GitHelper, commit handlers.CommitInfo) {
+	details, err := handlers.CommitDetails(gitHelper, commit.Hash)
+	if err != nil {
+		fmt.Fprintln(o.out, err)
+		return
+	}
+
+	if !commit.Conforming {
+		fmt.Fprintln(o.out, nonConformingFlag+"This message does not match the commit format")
+	}
+	fmt.Fprintln(o.out, details)
+
+	for {
+		action, ok := helpers.ShowSelect(commit.ShortHash, []helpers.SelectOption{
+			{Label: "Copy the SHA", Value: logActionCopySHA},
+			{Label: "Copy the message", Value: logActionCopyMessage},
+			{Label: "Back", Value: logActionBack},
+		})
+		if !ok || action == logActionBack {
+			return
+		}
+
+		text, what := commit.Hash, "SHA"
+		if action == logActionCopyMessage {
+			text, what = commit.Message, "message"
+		}
+
+		if err := helpers.CopyToClipboard(text); err != nil {
+			fmt.Fprintf(o.out, "Failed to copy the %s: %v\n", what, err)
+			continue
+		}
+		fmt.Fprintf(o.out, "Copied the %s to the clipboard\n", what)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// Options holds the per-run settings given on the command line. They take
+// precedence over the values from the config file.
+type Options struct {
+	Sign    string // settings.SignAlways, settings.SignNever or settings.SignAuto, empty keeps the config value
+	SignKey string
+	SignOff bool
+
+	NonInteractive bool   // take the commit from the form values and never prompt
+	Stage          string // StageAll, StageTracked or StageNone, empty stages as in interactive runs
+	Push           bool   // push after a non-interactive commit
+
+	events *eventWriter // progress events for -output json, nil for text output
+	out    io.Writer    // text output, stdout unless changed with WithOutput
+}
+
+// Staging modes of a non-interactive commit.
+const (
+	StageAll     = "all"     // stage every change, including untracked files
+	StageTracked = "tracked" // stage changes to tracked files only
+	StageNone    = "none"    // commit what is already staged
+)
+
+// Option changes a per-run setting.
+type Option func(*Options)
+
+// WithSigning overrides the signing mode and, when not empty, the signing key.
+func WithSigning(sign string, key string) Option {
+	return func(o *Options) {
+		o.Sign = sign
+		o.SignKey = key
+	}
+}
+
+// WithSignOff adds a Signed-off-by trailer to the commit.
+func WithSignOff(signOff bool) Option {
+	return func(o *Options) {
+		o.SignOff = signOff
+	}
+}
+
+// WithNonInteractive runs the commit without prompts, staging the changes
+// as given and pushing to the tracking remote only when push is set.
+func WithNonInteractive(stage string, push bool) Option {
+	return func(o *Options) {
+		o.NonInteractive = true
+		o.Stage = stage
+		o.Push = push
+	}
+}
+
+// WithOutput writes the text output to w instead of stdout.
+func WithOutput(w io.Writer) Option {
+	return func(o *Options) {
+		o.out = w
+	}
+}
+
+// newOptions applies the given options to Options writing text to stdout.
+func newOptions(opts []Option) *Options {
+	options := &Options{out: os.Stdout}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// applySigning applies the signing options to the config and resolves the
+// final signing settings for this run.
+func (o *Options) applySigning(gitHelper helpers.GitHelper, config *settings.Config) error {
+	switch o.Sign {
+	case "", settings.SignAuto, settings.SignAlways, settings.SignNever:
+	default:
+		return usageError("invalid signing mode %q, expected auto, always or never", o.Sign)
+	}
+
+	if o.Sign != "" {
+		config.Signing.Sign = o.Sign
+	}
+	if o.SignKey != "" {
+		config.Signing.Key = o.SignKey
+	}
+	if o.SignOff {
+		config.Signing.SignOff = true
+	}
+
+	config.Signing = handlers.ResolveSigning(gitHelper, config)
+
+	return nil
+}
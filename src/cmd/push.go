@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// RunPush runs the push command. It pushes the current branch the same way as
+// after a commit, without committing first.
+func RunPush(gitHelper helpers.GitHelper, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	branchName, err := handlers.GetCurrentBranch(gitHelper)
+	if err != nil {
+		return fmt.Errorf("failed to determine current branch: %w", err)
+	}
+
+	return pushBranch(gitHelper, config, options, branchName)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// RunRelease runs the release flow. It reads the commits since the last
+// release tag, computes the next semantic version from their commit types,
+// creates an annotated tag after confirmation and optionally pushes it.
+func RunRelease(gitHelper helpers.GitHelper, opts ...Option) error {
+	options := newOptions(opts)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	release, err := handlers.NextRelease(gitHelper, config)
+	if err != nil {
+		return err
+	}
+
+	if release.Bump == handlers.BumpNone {
+		since := "in the history"
+		if release.PreviousTag != "" {
+			since = "since " + release.PreviousTag
+		}
+		return fmt.Errorf("no commits %s call for a release", since)
+	}
+
+	tag := release.Tag(config)
+	message := handlers.ReleaseMessage(config, release)
+
+	previous := release.PreviousTag
+	if previous == "" {
+		previous = "no previous release"
+	}
+
+	title := fmt.Sprintf("Create the %s release %s (%s) with this message?\n\n%s", release.Bump, tag, previous, message)
+	if err := helpers.RequireConfirmation(title); err != nil {
+		return err
+	}
+	if !gitHelper.ShowConfirm(title, true) {
+		return canceled("release")
+	}
+
+	if err := handlers.CreateReleaseTag(gitHelper, tag, message); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(options.out, "Created tag %s\n", tag)
+
+	branchName, err := handlers.GetCurrentBranch(gitHelper)
+	if err != nil {
+		return nil
+	}
+
+	remotes, err := listRemotes(gitHelper)
+	if err != nil {
+		return nil
+	}
+
+	remote := handlers.TrackingRemote(gitHelper, branchName, remotes)
+	if remote == "" || !gitHelper.ShowConfirm(fmt.Sprintf("Do you want to push %s to %s?", tag, remote), config.Release.Push) {
+		return nil
+	}
+
+	if err := handlers.PushTag(gitHelper, remote, tag); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(options.out, "Pushed %s to %s\n", tag, remote)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// FormatCSV is the CSV output format of the report command.
+const FormatCSV = "csv"
+
+// RunReport runs the report command. It groups the commits between the refs
+// by reference and prints the report as Markdown, CSV or JSON. An empty from
+// starts at the last release tag and an empty to means HEAD.
+func RunReport(gitHelper helpers.GitHelper, from string, to string, format string, opts ...Option) error {
+	options := newOptions(opts)
+
+	if format == "" {
+		format = FormatMarkdown
+	}
+	if format != FormatMarkdown && format != FormatCSV && format != FormatJSON {
+		return usageError("invalid format %q, expected markdown, csv or json", format)
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	report, err := handlers.BuildReport(gitHelper, config, from, to)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatCSV:
+		data, err := report.CSV()
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(options.out, data)
+	case FormatJSON:
+		data, err := report.JSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Fprintln(options.out, string(data))
+	default:
+		fmt.Fprint(options.out, report.Markdown())
+	}
+
+	return nil
+}
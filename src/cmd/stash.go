@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// Actions offered for a stash on the stash screen.
+const (
+	stashActionShow  = "show"
+	stashActionApply = "apply"
+	stashActionPop   = "pop"
+	stashActionDrop  = "drop"
+	stashActionBack  = "back"
+	stashActionDone  = "action:done"
+)
+
+// RunStash runs the stash management screen. It lists the stashes and lets
+// the user show, apply, pop and drop them until they are done.
+func RunStash(gitHelper helpers.GitHelper, opts ...Option) error {
+	options := newOptions(opts)
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	for {
+		stashes, err := handlers.GetStashes(gitHelper)
+		if err != nil {
+			return err
+		}
+		if len(stashes) == 0 {
+			fmt.Fprintln(options.out, "There are no stashes")
+			return nil
+		}
+
+		choices := []helpers.SelectOption{{Label: "  Done", Value: stashActionDone}}
+		for _, stash := range stashes {
+			choices = append(choices, helpers.SelectOption{Label: stash.Label(), Value: stash.Ref})
+		}
+
+		ref, ok := helpers.ShowSelect("Stashes", choices)
+		if !ok || ref == stashActionDone {
+			return nil
+		}
+
+		if err := options.runStashAction(gitHelper, ref); err != nil {
+			fmt.Fprintln(options.out, err)
+		}
+	}
+}
+
+// runStashAction asks what to do with the stash and does it. Dropping a stash
+// asks for confirmation first.
+func (o *Options) runStashAction(gitHelper helpers.GitHelper, ref string) error {
+	action, ok := helpers.ShowSelect(ref, []helpers.SelectOption{
+		{Label: "Show the changes", Value: stashActionShow},
+		{Label: "Apply and keep the stash", Value: stashActionApply},
+		{Label: "Pop: apply and remove the stash", Value: stashActionPop},
+		{Label: "Drop without applying", Value: stashActionDrop},
+		{Label: "Back", Value: stashActionBack},
+	})
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case stashActionShow:
+		diff, err := handlers.ShowStash(gitHelper, ref)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.out, diff)
+	case stashActionApply:
+		if err := handlers.ApplyStash(gitHelper, ref); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.out, "Applied %s\n", ref)
+	case stashActionPop:
+		if err := handlers.PopStash(gitHelper, ref); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.out, "Popped %s\n", ref)
+	case stashActionDrop:
+		if !gitHelper.ShowConfirm(fmt.Sprintf("Do you want to drop %s? Its changes will be lost.", ref), false) {
+			return nil
+		}
+		if err := handlers.DropStash(gitHelper, ref); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.out, "Dropped %s\n", ref)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// FormatChart is the interactive chart output of the stats command.
+const FormatChart = "chart"
+
+// statsChartWidth is the width of the longest bar in the stats charts.
+const statsChartWidth = 40
+
+// Charts offered on the stats screen.
+const (
+	statsViewSummary   = "summary"
+	statsViewType      = "type"
+	statsViewAuthor    = "author"
+	statsViewReference = "reference"
+	statsViewWeek      = "week"
+	statsViewDone      = "action:done"
+)
+
+// RunStats runs the stats command. It reads the history in the revision
+// range, HEAD when empty, and either prints the stats as JSON or lets the
+// user browse them as charts.
+func RunStats(gitHelper helpers.GitHelper, revisionRange string, format string, opts ...Option) error {
+	options := newOptions(opts)
+
+	if format == "" {
+		format = FormatChart
+	}
+	if format != FormatChart && format != FormatJSON {
+		return usageError("invalid format %q, expected chart or json", format)
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := checkRepository(gitHelper); err != nil {
+		return err
+	}
+
+	stats, err := handlers.BuildStats(gitHelper, config, revisionRange)
+	if err != nil {
+		return err
+	}
+
+	if format == FormatJSON {
+		data, err := stats.JSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode stats: %w", err)
+		}
+		fmt.Fprintln(options.out, string(data))
+		return nil
+	}
+
+	fmt.Fprint(options.out, stats.Summary())
+	for {
+		view, ok := helpers.ShowSelect("Commit statistics", []helpers.SelectOption{
+			{Label: "Summary", Value: statsViewSummary},
+			{Label: "Commits by type", Value: statsViewType},
+			{Label: "Commits by author", Value: statsViewAuthor},
+			{Label: "Commits by reference", Value: statsViewReference},
+			{Label: "Commits by week", Value: statsViewWeek},
+			{Label: "Done", Value: statsViewDone},
+		})
+		if !ok || view == statsViewDone {
+			return nil
+		}
+
+		switch view {
+		case statsViewSummary:
+			fmt.Fprint(options.out, stats.Summary())
+		case statsViewType:
+			fmt.Fprint(options.out, handlers.BarChart(stats.ByType, statsChartWidth))
+		case statsViewAuthor:
+			fmt.Fprint(options.out, handlers.BarChart(stats.ByAuthor, statsChartWidth))
+		case statsViewReference:
+			fmt.Fprint(options.out, handlers.BarChart(stats.ByReference, statsChartWidth))
+		case statsViewWeek:
+			fmt.Fprint(options.out, handlers.BarChart(stats.ByWeek, statsChartWidth))
+		}
+	}
+}
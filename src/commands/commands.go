@@ -5,12 +5,84 @@ const (
 	GitInit          = "git init"
 	GitChangedFiles  = "git status --untracked-files=all --porcelain"
 	GitCommitAdd     = "git add -A"
+	GitAddTracked    = "git add -u"
 	GitCommitMessage = "git commit -m '%s'"
 	GitCurrentBranch = "git rev-parse --abbrev-ref HEAD"
 	GitGetRemote     = "git remote get-url origin"
 	GitSetRemote     = "git remote set-url origin %s"
-	GitPush          = "git push -u origin %s"
+	GitPush          = "git push -u %s %s"
 	GitStagedFiles   = "git diff --cached --name-only"
+	GitConfigGet     = "git config --get %s"
+	GitShortlog      = "git shortlog -sne HEAD"
+	GitDir           = "git rev-parse --absolute-git-dir"
+
+	GitLastCommitMessage = "git log -1 --format=%B"
+	GitUpstream          = "git rev-parse --abbrev-ref --symbolic-full-name @{u}"
+	GitIsAncestor        = "git merge-base --is-ancestor %s %s"
+	GitAmend             = "git commit --amend -m '%s'"
+	GitAmendMessageOnly  = "git commit --amend --only -m '%s'"
+
+	// GitLog prints one record per commit, separated by \x1e, with the fields
+	// hash, short hash, author name, author email, author date and body
+	// separated by \x1f. It takes the maximum number of commits and a revision
+	// range.
+	GitLog = "git log --format=%%H%%x1f%%h%%x1f%%an%%x1f%%ae%%x1f%%aI%%x1f%%B%%x1e -n %d %s"
+	// GitLogFiles prints each commit hash after a \x1e, followed by the files
+	// it touched, one per line.
+	GitLogFiles     = "git log --format=%%x1e%%H --name-only -n %d %s"
+	GitLogNumstat   = "git log --format=%%x1e%%H%%x20%%P --numstat -n %d %s"
+	GitCommitFixup  = "git commit --no-edit --fixup=%s"
+	GitCommitSquash = "git commit --no-edit --squash=%s"
+	GitRebaseSquash = "git -c sequence.editor=: -c core.editor=: rebase -i --autosquash --autostash %s"
+
+	GitRemotes     = "git remote -v"
+	GitPushRefspec = "git push %s %s:%s"
+	GitFetch       = "git fetch %s"
+	GitAheadBehind = "git rev-list --left-right --count %s...%s"
+
+	GitPullRebase      = "git pull --rebase %s %s"
+	GitPullMerge       = "git pull --no-rebase --no-edit %s %s"
+	GitConflictedFiles = "git diff --name-only --diff-filter=U"
+	GitTopLevel        = "git rev-parse --show-toplevel"
+	GitHooksPath       = "git rev-parse --git-path hooks"
+	GitAddFiles        = "git add -- %s"
+	GitRebaseContinue  = "git -c core.editor=true rebase --continue"
+	GitRebaseAbort     = "git rebase --abort"
+	GitMergeContinue   = "git commit --no-edit"
+	GitMergeAbort      = "git merge --abort"
+
+	GitCreateBranch = "git switch -c %s"
+
+	// GitBranches prints one line per local and remote branch, most recently
+	// committed first, with the full ref name, short name, short hash,
+	// relative commit date, author and subject separated by \x1f. It is not
+	// passed through fmt.Sprintf.
+	GitBranches         = "git for-each-ref --sort=-committerdate --format=%(refname)%1f%(refname:short)%1f%(objectname:short)%1f%(committerdate:relative)%1f%(authorname)%1f%(subject) refs/heads refs/remotes"
+	GitMergedBranches   = "git branch --merged %s --format=%%(refname:short)"
+	GitSwitchBranch     = "git switch %s"
+	GitSwitchTrack      = "git switch --track %s"
+	GitCreateBranchFrom = "git switch -c '%s' %s"
+	GitCheckBranchName  = "git check-ref-format --branch '%s'"
+	GitDeleteBranch     = "git branch -d %s"
+	GitStashPush        = "git stash push --include-untracked -m '%s'"
+
+	// GitStashList prints one line per stash with the stash ref, relative
+	// date and message separated by \x1f. It is not passed through fmt.Sprintf.
+	GitStashList  = "git stash list --format=%gd%x1f%cr%x1f%gs"
+	GitStashShow  = "git stash show --stat --patch %s"
+	GitStashApply = "git stash apply %s"
+	GitStashPop   = "git stash pop %s"
+	GitStashDrop  = "git stash drop %s"
+	GitStashFiles = "git stash push --include-untracked -m '%s' -- %s"
+
+	GitShowCommit = "git show --stat --patch --format=fuller %s"
+
+	GitLastTag   = "git describe --tags --abbrev=0 --match %s*"
+	GitCreateTag = "git tag -a %s -m '%s'"
+	GitPushTag   = "git push %s refs/tags/%s"
+
+	GitRevParse           = "git rev-parse --verify %s"
+	GitForcePushWithLease = "git push --force-with-lease=%s:%s %s %s:%s"
 
 	StagedFilesByExtension = `echo %s | grep '\.%s$'`
 	BinExits               = "command -v %s >/dev/null 2>&1"
@@ -0,0 +1,144 @@
+package forges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+)
+
+// requestTimeout bounds the whole pull request step.
+const requestTimeout = 30 * time.Second
+
+// ErrUnknownForge is returned when the forge cannot be detected from the
+// remote URL and the config does not name one.
+var ErrUnknownForge = errors.New("unknown forge")
+
+// PullRequestOptions describes the pull/merge request to create.
+type PullRequestOptions struct {
+	Head      string // source branch
+	Base      string // target branch, empty uses the repository's default branch
+	Title     string
+	Body      string
+	Reviewers []string
+	Labels    []string
+	Draft     bool
+}
+
+// PullRequest is a pull/merge request on a forge.
+type PullRequest struct {
+	Number   int
+	URL      string
+	Existing bool // true when an open request for the branch already existed
+}
+
+// Forge creates pull/merge requests on a code hosting service.
+type Forge interface {
+	// Name returns the name of the forge shown to the user.
+	Name() string
+
+	// DefaultBranch returns the repository's default branch.
+	DefaultBranch(ctx context.Context) (string, error)
+
+	// FindOrCreatePullRequest returns the open request for the head branch, or
+	// creates one when there is none.
+	FindOrCreatePullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error)
+}
+
+// New returns the forge for the pull request config. The forge is taken from
+// the config or detected from the remote URL, and the project is detected from
+// the remote URL when the forge config does not name one. It returns
+// ErrUnknownForge when the remote is on neither github.com, GitLab nor a
+// configured host.
+func New(config *settings.Config, remoteURL string) (Forge, error) {
+	forge := config.PullRequest.Forge
+	if forge == "" {
+		forge = detectForge(config, remoteURL)
+	}
+	if forge == "" {
+		return nil, fmt.Errorf("%w: cannot tell the forge of remote %q, set pull_request.forge in the config", ErrUnknownForge, remoteURL)
+	}
+
+	switch forge {
+	case settings.TrackerGitHub:
+		return newGitHub(config.GitHub, remoteURL)
+	case settings.TrackerGitLab:
+		return newGitLab(config.GitLab, remoteURL)
+	default:
+		return nil, fmt.Errorf("unsupported forge %q, expected github or gitlab", forge)
+	}
+}
+
+// WithTimeout returns a context for the pull request step.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, requestTimeout)
+}
+
+// detectForge guesses the forge from the remote URL and configured base URLs.
+// It returns an empty string for other hosts, such as Bitbucket or Gitea.
+func detectForge(config *settings.Config, remoteURL string) string {
+	remoteURL = strings.ToLower(remoteURL)
+	switch {
+	case strings.Contains(remoteURL, "gitlab"), onHost(config.GitLab.BaseURL, remoteURL):
+		return settings.TrackerGitLab
+	case strings.Contains(remoteURL, "github.com"), onHost(config.GitHub.BaseURL, remoteURL):
+		return settings.TrackerGitHub
+	}
+	return ""
+}
+
+// onHost reports whether the remote URL is on the host of the base URL.
+func onHost(baseURL string, remoteURL string) bool {
+	host := hostOf(strings.ToLower(baseURL))
+	return host != "" && strings.Contains(remoteURL, host)
+}
+
+// hostOf returns the host part of a URL such as "https://gitlab.example.com/".
+func hostOf(rawURL string) string {
+	_, rest, found := strings.Cut(rawURL, "://")
+	if !found {
+		rest = rawURL
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	return host
+}
+
+// projectOf returns the configured project, or the project of the remote URL.
+func projectOf(config settings.Forge, remoteURL string) (string, error) {
+	project := strings.Trim(config.Project, "/")
+	if project == "" {
+		project = trackers.ProjectFromRemoteURL(remoteURL)
+	}
+	if project == "" {
+		return "", fmt.Errorf("cannot determine the project from remote %q, set it in the config", remoteURL)
+	}
+	return project, nil
+}
+
+// apiClient sends JSON requests to a forge API.
+type apiClient struct {
+	name       string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response
+// into target, if given.
+func (c *apiClient) do(ctx context.Context, method string, endpoint string, body any, target any) error {
+	request, err := helpers.NewJSONRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", c.name, err)
+	}
+
+	for key, value := range c.headers {
+		request.Header.Set(key, value)
+	}
+
+	return helpers.SendJSON(c.httpClient, request, c.name, target)
+}
@@ -0,0 +1,130 @@
+package forges
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const defaultGitHubAPI = "https://api.github.com"
+
+// GitHub creates pull requests through the GitHub REST API.
+type GitHub struct {
+	BaseURL string
+	Project string // "owner/repo"
+	api     *apiClient
+}
+
+// githubPullRequest is the subset of the GitHub pull request resource used.
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// newGitHub returns a GitHub forge for the config, with the GITHUB_TOKEN
+// environment variable taking precedence over the token.
+func newGitHub(config settings.Forge, remoteURL string) (*GitHub, error) {
+	project, err := projectOf(config, remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimRight(config.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultGitHubAPI
+	}
+
+	headers := map[string]string{"X-GitHub-Api-Version": "2022-11-28"}
+	if token := helpers.EnvOrDefault("GITHUB_TOKEN", config.Token); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	return &GitHub{
+		BaseURL: baseURL,
+		Project: project,
+		api:     &apiClient{name: "GitHub", headers: headers, httpClient: helpers.HTTPClient},
+	}, nil
+}
+
+// Name returns the name of the forge.
+func (g *GitHub) Name() string {
+	return "GitHub"
+}
+
+// DefaultBranch returns the repository's default branch.
+func (g *GitHub) DefaultBranch(ctx context.Context) (string, error) {
+	var repository struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.repoURL(), nil, &repository); err != nil {
+		return "", err
+	}
+	return repository.DefaultBranch, nil
+}
+
+// FindOrCreatePullRequest returns the open pull request for the head branch,
+// or creates one and requests the reviewers and adds the labels.
+func (g *GitHub) FindOrCreatePullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error) {
+	owner, _, _ := strings.Cut(g.Project, "/")
+
+	query := url.Values{}
+	query.Set("head", owner+":"+options.Head)
+	query.Set("state", "open")
+
+	var existing []githubPullRequest
+	if err := g.api.do(ctx, http.MethodGet, g.repoURL()+"/pulls?"+query.Encode(), nil, &existing); err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return &PullRequest{Number: existing[0].Number, URL: existing[0].HTMLURL, Existing: true}, nil
+	}
+
+	if options.Base == "" {
+		base, err := g.DefaultBranch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options.Base = base
+	}
+
+	body := map[string]any{
+		"title": options.Title,
+		"head":  options.Head,
+		"base":  options.Base,
+		"body":  options.Body,
+		"draft": options.Draft,
+	}
+
+	var created githubPullRequest
+	if err := g.api.do(ctx, http.MethodPost, g.repoURL()+"/pulls", body, &created); err != nil {
+		return nil, err
+	}
+
+	pullRequest := &PullRequest{Number: created.Number, URL: created.HTMLURL}
+
+	if len(options.Reviewers) > 0 {
+		endpoint := fmt.Sprintf("%s/pulls/%d/requested_reviewers", g.repoURL(), created.Number)
+		if err := g.api.do(ctx, http.MethodPost, endpoint, map[string]any{"reviewers": options.Reviewers}, nil); err != nil {
+			return pullRequest, fmt.Errorf("pull request created but requesting reviewers failed: %w", err)
+		}
+	}
+
+	if len(options.Labels) > 0 {
+		endpoint := fmt.Sprintf("%s/issues/%d/labels", g.repoURL(), created.Number)
+		if err := g.api.do(ctx, http.MethodPost, endpoint, map[string]any{"labels": options.Labels}, nil); err != nil {
+			return pullRequest, fmt.Errorf("pull request created but adding labels failed: %w", err)
+		}
+	}
+
+	return pullRequest, nil
+}
+
+// repoURL returns the API URL of the repository.
+func (g *GitHub) repoURL() string {
+	return fmt.Sprintf("%s/repos/%s", g.BaseURL, g.Project)
+}
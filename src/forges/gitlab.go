@@ -0,0 +1,147 @@
+package forges
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const defaultGitLabURL = "https://gitlab.com"
+
+// GitLab creates merge requests through the GitLab REST API.
+type GitLab struct {
+	BaseURL string
+	Project string // "group/project", subgroups allowed
+	api     *apiClient
+}
+
+// gitlabMergeRequest is the subset of the GitLab merge request resource used.
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+// newGitLab returns a GitLab forge for the config, with the GITLAB_TOKEN
+// environment variable taking precedence over the token.
+func newGitLab(config settings.Forge, remoteURL string) (*GitLab, error) {
+	project, err := projectOf(config, remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimRight(config.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultGitLabURL
+	}
+
+	headers := map[string]string{}
+	if token := helpers.EnvOrDefault("GITLAB_TOKEN", config.Token); token != "" {
+		headers["PRIVATE-TOKEN"] = token
+	}
+
+	return &GitLab{
+		BaseURL: baseURL,
+		Project: project,
+		api:     &apiClient{name: "GitLab", headers: headers, httpClient: helpers.HTTPClient},
+	}, nil
+}
+
+// Name returns the name of the forge.
+func (g *GitLab) Name() string {
+	return "GitLab"
+}
+
+// DefaultBranch returns the project's default branch.
+func (g *GitLab) DefaultBranch(ctx context.Context) (string, error) {
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.projectURL(), nil, &project); err != nil {
+		return "", err
+	}
+	return project.DefaultBranch, nil
+}
+
+// FindOrCreatePullRequest returns the open merge request for the head branch,
+// or creates one with the reviewers and labels.
+func (g *GitLab) FindOrCreatePullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("source_branch", options.Head)
+	query.Set("state", "opened")
+
+	var existing []gitlabMergeRequest
+	if err := g.api.do(ctx, http.MethodGet, g.projectURL()+"/merge_requests?"+query.Encode(), nil, &existing); err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return &PullRequest{Number: existing[0].IID, URL: existing[0].WebURL, Existing: true}, nil
+	}
+
+	if options.Base == "" {
+		base, err := g.DefaultBranch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options.Base = base
+	}
+
+	title := options.Title
+	if options.Draft {
+		title = "Draft: " + title
+	}
+
+	body := map[string]any{
+		"source_branch": options.Head,
+		"target_branch": options.Base,
+		"title":         title,
+		"description":   options.Body,
+	}
+	if len(options.Labels) > 0 {
+		body["labels"] = strings.Join(options.Labels, ",")
+	}
+	if len(options.Reviewers) > 0 {
+		reviewerIDs, err := g.userIDs(ctx, options.Reviewers)
+		if err != nil {
+			return nil, err
+		}
+		body["reviewer_ids"] = reviewerIDs
+	}
+
+	var created gitlabMergeRequest
+	if err := g.api.do(ctx, http.MethodPost, g.projectURL()+"/merge_requests", body, &created); err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{Number: created.IID, URL: created.WebURL}, nil
+}
+
+// userIDs resolves user names to the numeric IDs GitLab expects for reviewers.
+func (g *GitLab) userIDs(ctx context.Context, usernames []string) ([]int, error) {
+	var ids []int
+	for _, username := range usernames {
+		var users []struct {
+			ID int `json:"id"`
+		}
+
+		endpoint := fmt.Sprintf("%s/api/v4/users?username=%s", g.BaseURL, url.QueryEscape(strings.TrimPrefix(username, "@")))
+		if err := g.api.do(ctx, http.MethodGet, endpoint, nil, &users); err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("GitLab user %q not found", username)
+		}
+
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// projectURL returns the API URL of the project.
+func (g *GitLab) projectURL() string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", g.BaseURL, url.PathEscape(g.Project))
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// ValuesSetter is implemented by commit forms that can be pre-filled with every
+// value, including the summary.
+type ValuesSetter interface {
+	SetValues(version, commitType, jira, summary string)
+}
+
+// GetLastCommitMessage returns the full message of the HEAD commit, or an error
+// if the repository has no commits yet.
+func GetLastCommitMessage(helper helpers.GitHelper) (string, error) {
+	output, err := helper.ExecuteCommand(commands.GitLastCommitMessage)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the last commit: %w", err)
+	}
+
+	message := strings.TrimSpace(output)
+	if message == "" {
+		return "", fmt.Errorf("the last commit has no message")
+	}
+
+	return message, nil
+}
+
+// IsHeadPushed reports whether the HEAD commit is already contained in the
+// upstream branch. It returns the upstream name and false when the branch has
+// no upstream.
+func IsHeadPushed(helper helpers.GitHelper) (upstream string, pushed bool) {
+	output, err := helper.ExecuteCommand(commands.GitUpstream)
+	if err != nil {
+		return "", false
+	}
+
+	upstream = strings.TrimSpace(output)
+	if upstream == "" {
+		return "", false
+	}
+
+	_, err = helper.ExecuteCommand(fmt.Sprintf(commands.GitIsAncestor, "HEAD", "@{u}"))
+	return upstream, err == nil
+}
+
+// PrefillAmendForm reverse-parses the last commit message through the commit
+// format and pre-fills the form with the recovered values, including the
+// co-authors and other trailers of the message. When the message does not
+// match the format, the message without its trailers is used as the summary
+// and false is returned.
+func PrefillAmendForm(config *settings.Config, form CommitForm, message string) bool {
+	parsed, ok := ParseCommitMessage(config, message)
+	if !ok {
+		summary, coAuthors, trailers := splitTrailers(strings.TrimSpace(message))
+		parsed = ParsedCommit{
+			Version:    config.DefaultVersion,
+			CommitType: config.DefaultCommitType,
+			Jira:       config.DefaultJiraReference,
+			Summary:    summary,
+			CoAuthors:  coAuthors,
+			Trailers:   trailers,
+		}
+	}
+
+	if parsed.CommitType == "" {
+		parsed.CommitType = config.DefaultCommitType
+	}
+
+	form.SetDefaultValues(config.CommitTypes, parsed.CommitType, parsed.Version, parsed.Jira)
+	if setter, ok := form.(ValuesSetter); ok {
+		setter.SetValues(parsed.Version, parsed.CommitType, parsed.Jira, parsed.Summary)
+	}
+	if coAuthorForm, ok := form.(CoAuthorForm); ok && len(parsed.CoAuthors) > 0 {
+		coAuthorForm.SetCoAuthors(parsed.CoAuthors, parsed.CoAuthors)
+	}
+	if trailerForm, ok := form.(TrailerForm); ok {
+		trailerForm.SetTrailers(parsed.Trailers)
+	}
+
+	return ok
+}
+
+// ShowAmendUI displays the commit form for amending the last commit. When
+// includeStaged is false only the message is amended and any staged changes
+// are left in the index. On a protected branch the amended commit goes to a
+// new feature branch, as for new commits. Returns ErrCommitCanceled if the amend was not
+// confirmed, or the git error if it fails.
+func ShowAmendUI(helper helpers.GitHelper, config *settings.Config, form CommitForm, includeStaged bool) error {
+	amendCommand := commands.GitAmendMessageOnly
+	if includeStaged {
+		amendCommand = commands.GitAmend
+	}
+
+	return runCommitForm(helper, config, form, "Amend last commit with following message?\n", amendCommand, true)
+}
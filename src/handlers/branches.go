@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// Ways to deal with uncommitted changes when switching branches.
+const (
+	CarryChanges = "carry"
+	StashChanges = "stash"
+)
+
+// ErrSwitchCanceled is returned when the user cancels switching branches.
+var ErrSwitchCanceled = errors.New("switch canceled")
+
+// Branch is a local or remote branch with its last commit.
+type Branch struct {
+	Ref       string // full ref name, e.g. "refs/remotes/origin/main"
+	Name      string // short name, e.g. "origin/main"
+	Remote    bool
+	Current   bool
+	ShortHash string
+	Date      string // relative commit date, e.g. "2 days ago"
+	Author    string
+	Subject   string
+}
+
+// LocalName returns the name of the local branch, without the remote for
+// remote branches, e.g. "main" for "origin/main".
+func (b Branch) LocalName() string {
+	if !b.Remote {
+		return b.Name
+	}
+	_, name, _ := strings.Cut(b.Name, "/")
+	return name
+}
+
+// Label returns a one line description of the branch and its last commit.
+func (b Branch) Label() string {
+	marker := "  "
+	if b.Current {
+		marker = "* "
+	}
+	return fmt.Sprintf("%s%s  %s  %s  %s: %s", marker, b.Name, b.ShortHash, b.Date, b.Author, b.Subject)
+}
+
+// GetBranches returns the local and remote branches, most recently committed
+// first. The current branch is marked and the remotes' HEAD aliases are left
+// out.
+func GetBranches(helper helpers.GitHelper) ([]Branch, error) {
+	output, err := helper.ExecuteCommand(commands.GitBranches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	current, _ := GetCurrentBranch(helper)
+
+	var branches []Branch
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 6 || strings.HasSuffix(fields[0], "/HEAD") {
+			continue
+		}
+
+		remote := strings.HasPrefix(fields[0], "refs/remotes/")
+		branches = append(branches, Branch{
+			Ref:       fields[0],
+			Name:      fields[1],
+			Remote:    remote,
+			Current:   !remote && fields[1] == current,
+			ShortHash: fields[2],
+			Date:      fields[3],
+			Author:    fields[4],
+			Subject:   strings.TrimSpace(fields[5]),
+		})
+	}
+
+	return branches, nil
+}
+
+// HasUncommittedChanges reports whether the working tree has changes, including
+// untracked files.
+func HasUncommittedChanges(helper helpers.GitHelper) bool {
+	output, err := helper.ExecuteCommand(commands.GitChangedFiles)
+	return err == nil && strings.TrimSpace(output) != ""
+}
+
+// AskUncommittedChanges asks what to do with uncommitted changes before
+// switching to the target branch: carry them over or stash them. It returns
+// CarryChanges when the working tree is clean.
+func AskUncommittedChanges(helper helpers.GitHelper, target string) (string, error) {
+	if !HasUncommittedChanges(helper) {
+		return CarryChanges, nil
+	}
+
+	choice, ok := helpers.ShowSelect(fmt.Sprintf("You have uncommitted changes. What do you want to do with them when switching to '%s'?", target), []helpers.SelectOption{
+		{Label: "Carry them over to the branch", Value: CarryChanges},
+		{Label: "Stash them", Value: StashChanges},
+		{Label: "Cancel", Value: ""},
+	})
+	if !ok || choice == "" {
+		return "", ErrSwitchCanceled
+	}
+
+	return choice, nil
+}
+
+// SwitchBranch checks out the branch. A remote branch is checked out as a new
+// local branch tracking it, or as the existing local branch of the same name.
+// Uncommitted changes are carried over or stashed first.
+func SwitchBranch(helper helpers.GitHelper, branch Branch, branches []Branch, changes string) error {
+	if changes == StashChanges {
+		if err := stashForSwitch(helper, branch.LocalName()); err != nil {
+			return err
+		}
+	}
+
+	cmd := fmt.Sprintf(commands.GitSwitchBranch, branch.Name)
+	if branch.Remote {
+		cmd = fmt.Sprintf(commands.GitSwitchTrack, branch.Name)
+		for _, local := range branches {
+			if !local.Remote && local.Name == branch.LocalName() {
+				cmd = fmt.Sprintf(commands.GitSwitchBranch, local.Name)
+				break
+			}
+		}
+	}
+
+	return runGitAction(helper, cmd, "switch to '"+branch.Name+"'")
+}
+
+// CreateBranch creates a branch from the base, which may be any branch or
+// commit, and switches to it. Uncommitted changes are carried over or stashed
+// first.
+func CreateBranch(helper helpers.GitHelper, name string, base string, changes string) error {
+	if changes == StashChanges {
+		if err := stashForSwitch(helper, name); err != nil {
+			return err
+		}
+	}
+
+	return runGitAction(helper, fmt.Sprintf(commands.GitCreateBranchFrom, helpers.EscapeSingleQuotes(name), base), "create '"+name+"'")
+}
+
+// ValidateBranchName returns an error when git does not accept the name for a
+// new branch.
+func ValidateBranchName(helper helpers.GitHelper, name string) error {
+	if _, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitCheckBranchName, helpers.EscapeSingleQuotes(name))); err != nil {
+		return fmt.Errorf("'%s' is not a valid branch name: %w", name, err)
+	}
+	return nil
+}
+
+// MergedBranches returns the local branches fully merged into the base,
+// leaving out the base itself, the current branch and protected branches.
+func MergedBranches(helper helpers.GitHelper, config *settings.Config, base string) ([]string, error) {
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitMergedBranches, base))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged branches: %w", err)
+	}
+
+	current, _ := GetCurrentBranch(helper)
+
+	var merged []string
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		if name == "" || name == base || name == current || IsProtectedBranch(config, name) {
+			continue
+		}
+		merged = append(merged, name)
+	}
+
+	return merged, nil
+}
+
+// DeleteBranches deletes the local branches with 'git branch -d', which
+// refuses to delete unmerged work, and returns the result for each branch.
+func DeleteBranches(helper helpers.GitHelper, names []string) map[string]error {
+	results := make(map[string]error, len(names))
+	for _, name := range names {
+		results[name] = runGitAction(helper, fmt.Sprintf(commands.GitDeleteBranch, name), "delete '"+name+"'")
+	}
+	return results
+}
+
+// stashForSwitch stashes the uncommitted changes with a message naming the
+// branch being switched to.
+func stashForSwitch(helper helpers.GitHelper, target string) error {
+	return runGitAction(helper, fmt.Sprintf(commands.GitStashPush, helpers.EscapeSingleQuotes("Before switching to "+target)), "stash the changes")
+}
+
+// runGitAction runs a git command for the named action, logging the output
+// when it fails.
+func runGitAction(helper helpers.GitHelper, cmd string, action string) error {
+	output, err := helper.ExecuteCommand(cmd)
+	if err != nil {
+		log.Printf("Failed to %s: %v\nCommand: %q\nOutput: %q", action, err, cmd, output)
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+)
+
+const (
+	defaultChangelogFile   = "CHANGELOG.md"
+	changelogHeader        = "# Changelog"
+	changelogCommitLimit   = 5000
+	unreleasedChangelogRef = "Unreleased"
+)
+
+// defaultChangelogSections are used when the config file predates the
+// changelog settings.
+var defaultChangelogSections = []settings.ChangelogSection{
+	{Type: "feat", Title: "Features"},
+	{Type: "fix", Title: "Bug Fixes"},
+	{Type: "perf", Title: "Performance"},
+	{Type: "refactor", Title: "Refactoring"},
+	{Type: "revert", Title: "Reverts"},
+	{Type: "db", Title: "Database"},
+	{Type: "docs", Title: "Documentation"},
+}
+
+// ChangelogEntry is a commit in the changelog, parsed through the commit format.
+type ChangelogEntry struct {
+	Hash         string    `json:"hash"`
+	Version      string    `json:"version"`
+	Type         string    `json:"type"`
+	Reference    string    `json:"reference"`
+	ReferenceURL string    `json:"reference_url,omitempty"`
+	Summary      string    `json:"summary"`
+	Author       string    `json:"author"`
+	Date         time.Time `json:"date"`
+}
+
+// ChangelogSection holds the entries of one commit type.
+type ChangelogSection struct {
+	Type    string           `json:"type"`
+	Title   string           `json:"title"`
+	Entries []ChangelogEntry `json:"entries"`
+}
+
+// Changelog lists the changes between two refs, grouped by commit type.
+type Changelog struct {
+	From     string             `json:"from"` // empty when the changelog starts at the first commit
+	To       string             `json:"to"`
+	Title    string             `json:"title"` // the release tag, or "Unreleased"
+	Date     time.Time          `json:"date"`  // date of the newest commit
+	Sections []ChangelogSection `json:"sections"`
+}
+
+// BuildChangelog reads the commits between the refs and groups them by the
+// section types in the config, in the config's order. An empty from starts at
+// the last release tag, or the first commit when there is none, and an empty
+// to means HEAD. Commits that do not match the commit format, or whose type has
+// no section, are left out. References are linked to their issue on the
+// tracker, when one is given.
+func BuildChangelog(helper helpers.GitHelper, config *settings.Config, tracker trackers.Tracker, from string, to string) (*Changelog, error) {
+	from, to, revisionRange := releaseRange(helper, config, from, to)
+
+	commits, err := GetCommits(helper, config, revisionRange, changelogCommitLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	changelog := &Changelog{From: from, To: to, Title: unreleasedChangelogRef}
+	if _, ok := ParseVersion(to, ReleaseTagPrefix(config)); ok {
+		changelog.Title = to
+	}
+	if len(commits) > 0 {
+		changelog.Date = commits[0].Date
+	}
+
+	sections := config.Changelog.Sections
+	if sections == nil {
+		sections = defaultChangelogSections
+	}
+
+	for _, section := range sections {
+		entries := []ChangelogEntry{}
+		for _, commit := range commits {
+			if commit.Conforming && commit.Parsed.CommitType == section.Type {
+				entries = append(entries, newChangelogEntry(tracker, commit))
+			}
+		}
+
+		if len(entries) > 0 {
+			changelog.Sections = append(changelog.Sections, ChangelogSection{Type: section.Type, Title: section.Title, Entries: entries})
+		}
+	}
+
+	return changelog, nil
+}
+
+// Markdown renders the changelog as a Markdown release section.
+func (c *Changelog) Markdown() string {
+	var builder strings.Builder
+
+	builder.WriteString("## " + c.Title)
+	if !c.Date.IsZero() {
+		builder.WriteString(" (" + c.Date.Format(time.DateOnly) + ")")
+	}
+	builder.WriteString("\n")
+
+	if len(c.Sections) == 0 {
+		builder.WriteString("\nNo notable changes.\n")
+	}
+
+	for _, section := range c.Sections {
+		builder.WriteString("\n### " + section.Title + "\n\n")
+		for _, entry := range section.Entries {
+			builder.WriteString("- " + entry.Summary)
+			switch {
+			case entry.ReferenceURL != "":
+				builder.WriteString(fmt.Sprintf(" ([%s](%s))", entry.Reference, entry.ReferenceURL))
+			case entry.Reference != "":
+				builder.WriteString(" (" + entry.Reference + ")")
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// JSON renders the changelog as indented JSON.
+func (c *Changelog) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// ChangelogFile returns the Markdown file the changelog is written to.
+func ChangelogFile(config *settings.Config) string {
+	if config.Changelog.File == "" {
+		return defaultChangelogFile
+	}
+	return config.Changelog.File
+}
+
+// WriteChangelog writes the Markdown section to the changelog file. A section
+// with the same "## <version>" heading is replaced in place, so writing the
+// changelog of a release again does not add it twice; otherwise the section is
+// added to the top, below the "# Changelog" header. The file is created with
+// the header when it does not exist.
+func WriteChangelog(path string, markdown string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	rest := strings.TrimLeft(string(existing), "\n")
+	if after, found := strings.CutPrefix(rest, changelogHeader+"\n"); found {
+		rest = strings.TrimLeft(after, "\n")
+	}
+
+	section := strings.TrimRight(markdown, "\n") + "\n"
+	before, after, found := cutChangelogSection(rest, changelogHeading(markdown))
+	if !found {
+		before, after = "", rest
+	}
+
+	content := changelogHeader + "\n\n" + before + section
+	if after != "" {
+		content += "\n" + after
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// changelogHeading returns the "## <version>" heading of a changelog section,
+// without the date that follows the version.
+func changelogHeading(markdown string) string {
+	line, _, _ := strings.Cut(strings.TrimLeft(markdown, "\n"), "\n")
+	heading, _, _ := strings.Cut(strings.TrimSpace(line), " (")
+	return heading
+}
+
+// cutChangelogSection finds the section with the heading in the changelog and
+// returns the text before and after it. It reports false when there is no
+// such section.
+func cutChangelogSection(changelog string, heading string) (before string, after string, found bool) {
+	lines := strings.SplitAfter(changelog, "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") && changelogHeading(line) == heading {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return "", "", false
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "## ") {
+			end = i
+			break
+		}
+	}
+
+	return strings.Join(lines[:start], ""), strings.Join(lines[end:], ""), true
+}
+
+// releaseRange fills in the default refs of a range: an empty to means HEAD
+// and an empty from is the last release tag, or stays empty when there is no
+// tag so the range starts at the first commit. It returns the refs and the
+// revision range to pass to git log.
+func releaseRange(helper helpers.GitHelper, config *settings.Config, from string, to string) (string, string, string) {
+	if to == "" {
+		to = "HEAD"
+	}
+	if from == "" {
+		if output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitLastTag, ReleaseTagPrefix(config))); err == nil {
+			from = strings.TrimSpace(output)
+		}
+	}
+
+	if from == "" {
+		return from, to, to
+	}
+	return from, to, from + ".." + to
+}
+
+// newChangelogEntry turns a conforming commit into a changelog entry.
+func newChangelogEntry(tracker trackers.Tracker, commit CommitInfo) ChangelogEntry {
+	summary, _, _ := strings.Cut(commit.Parsed.Summary, "\n")
+
+	entry := ChangelogEntry{
+		Hash:      commit.Hash,
+		Version:   commit.Parsed.Version,
+		Type:      commit.Parsed.CommitType,
+		Reference: commit.Parsed.Jira,
+		Summary:   strings.TrimSpace(summary),
+		Author:    commit.Author,
+		Date:      commit.Date,
+	}
+
+	if entry.Reference != "" && tracker != nil {
+		entry.ReferenceURL = tracker.IssueURL(entry.Reference)
+	}
+
+	return entry
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const coAuthorTrailer = "Co-authored-by:"
+
+// trailerPattern matches a trailer line such as "Signed-off-by: Jane Doe", or
+// an issue closing line such as "Closes #123" or "Fixes group/project#12".
+var trailerPattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9-]*:\s+\S|(?i:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+(?:[\w.-]+/)*[\w.-]*#[0-9]+$)`)
+
+// CoAuthorForm is implemented by commit forms that let the user pick
+// co-authors for the commit.
+type CoAuthorForm interface {
+	SetCoAuthors(candidates []string, selected []string)
+	GetCoAuthors() []string
+}
+
+// TrailerForm is implemented by commit forms that keep the trailers of an
+// amended commit, other than the co-authors, so they are written again.
+type TrailerForm interface {
+	SetTrailers(trailers []string)
+	GetTrailers() []string
+}
+
+// GetCoAuthorCandidates returns the people that can be picked as co-authors,
+// formatted as "Name <email>". It combines the team roster from the config with
+// the authors from 'git shortlog -sne', most active first, and leaves out the
+// current git user.
+func GetCoAuthorCandidates(helper helpers.GitHelper, config *settings.Config) []string {
+	currentEmail := strings.ToLower(gitConfigValue(helper, "user.email"))
+
+	seen := map[string]bool{}
+	var candidates []string
+	add := func(author string) {
+		formatted, email, ok := normaliseCoAuthor(author)
+		if !ok || seen[email] || (currentEmail != "" && email == currentEmail) {
+			return
+		}
+		seen[email] = true
+		candidates = append(candidates, formatted)
+	}
+
+	for _, member := range config.TeamRoster {
+		add(member)
+	}
+
+	output, err := helper.ExecuteCommand(commands.GitShortlog)
+	if err == nil {
+		for _, line := range strings.Split(output, "\n") {
+			// Each line looks like "    12\tJane Doe <jane@example.com>"
+			if _, author, found := strings.Cut(line, "\t"); found {
+				add(author)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// CoAuthorTrailers formats the co-authors as 'Co-authored-by' trailers.
+func CoAuthorTrailers(coAuthors []string) []string {
+	var trailers []string
+	for _, coAuthor := range coAuthors {
+		if formatted, _, ok := normaliseCoAuthor(coAuthor); ok {
+			trailers = append(trailers, fmt.Sprintf("%s %s", coAuthorTrailer, formatted))
+		}
+	}
+	return trailers
+}
+
+// splitTrailers removes the trailer block, the last paragraph of a commit
+// message when every line of it is a trailer, returning the remaining message,
+// the co-authors and the other trailers, such as 'Signed-off-by', in their
+// original order.
+func splitTrailers(message string) (string, []string, []string) {
+	message = strings.TrimRight(message, "\n")
+	cut := strings.LastIndex(message, "\n\n")
+	if cut < 0 {
+		return message, nil, nil
+	}
+
+	var coAuthors, trailers []string
+	for _, line := range strings.Split(message[cut+2:], "\n") {
+		line = strings.TrimSpace(line)
+		if !trailerPattern.MatchString(line) {
+			return message, nil, nil
+		}
+
+		if strings.HasPrefix(strings.ToLower(line), strings.ToLower(coAuthorTrailer)) {
+			coAuthors = append(coAuthors, strings.TrimSpace(line[len(coAuthorTrailer):]))
+			continue
+		}
+		trailers = append(trailers, line)
+	}
+
+	return strings.TrimSpace(message[:cut]), coAuthors, trailers
+}
+
+// normaliseCoAuthor parses "Name <email>" and returns it in a consistent format
+// together with the lower case email address.
+func normaliseCoAuthor(author string) (formatted string, email string, ok bool) {
+	author = strings.TrimSpace(author)
+	open := strings.LastIndex(author, "<")
+	if open <= 0 || !strings.HasSuffix(author, ">") {
+		return "", "", false
+	}
+
+	name := strings.TrimSpace(author[:open])
+	address := strings.TrimSpace(author[open+1 : len(author)-1])
+	if name == "" || !strings.Contains(address, "@") {
+		return "", "", false
+	}
+
+	return fmt.Sprintf("%s <%s>", name, address), strings.ToLower(address), true
+}
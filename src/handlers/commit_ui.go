@@ -3,13 +3,14 @@ package handlers
 import (
 	"errors"
 	"fmt"
-	"log"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/kurianvarkey/gitcommitui/src/commands"
 	"github.com/kurianvarkey/gitcommitui/src/helpers"
 	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
 )
 
 // Interface to abstract the form
@@ -19,10 +20,27 @@ type CommitForm interface {
 	GetValues() (version, commitType, jira, summary string)
 }
 
+// FormValidator is implemented by commit forms whose values can be checked
+// before the form is run, such as forms filled from flags.
+type FormValidator interface {
+	Validate() error
+}
+
+// ErrCommitCanceled is returned when the commit form or its confirmation is
+// canceled, or the commit was not allowed on the current branch.
+var ErrCommitCanceled = errors.New("commit canceled")
+
 // Struct to encapsulate form values and logic
 type DefaultCommitForm struct {
 	Version, CommitType, Jira, Summary string
 	Types                              []string
+	TypeHint                           string
+	History                            *FormHistory
+	CoAuthors, CoAuthorCandidates      []string
+	Trailers                           []string
+	References                         *ReferenceLookup
+
+	recalled HistoryEntry // recent commit picked in the form, zero when none
 }
 
 var defaultCommitTypes = []string{
@@ -43,19 +61,105 @@ func (f *DefaultCommitForm) Run() error {
 		options[i] = huh.NewOption(v, v)
 	}
 
-	return huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().Title("Version").Value(&f.Version).Placeholder("1.x"),
-			huh.NewSelect[string]().Title("Commit Type").Options(options...).Value(&f.CommitType),
-			huh.NewInput().Title("Reference").Value(&f.Jira).Placeholder("Jira ticket if any"),
-			huh.NewText().Title("Summary").Value(&f.Summary).Placeholder("Summary of change").Validate(func(s string) error {
-				if s == "" {
-					return errors.New("summary cannot be empty")
-				}
-				return nil
-			}),
-		),
-	).WithTheme(settings.HuhTheme).Run()
+	var versions, references []string
+	if f.History != nil {
+		versions, references = f.History.Versions(), f.History.References()
+	}
+
+	fields := []huh.Field{
+		huh.NewInput().Title("Version").Value(&f.Version).Placeholder("1.x").Suggestions(versions),
+		huh.NewSelect[string]().Title("Commit Type").Description(f.TypeHint).Options(options...).Value(&f.CommitType),
+		f.referenceInput(references),
+		huh.NewText().Title("Summary").Value(&f.Summary).Placeholder("Summary of change").Validate(func(s string) error {
+			if s == "" {
+				return errors.New("summary cannot be empty")
+			}
+			return nil
+		}),
+	}
+
+	if f.History != nil && len(f.History.Entries) > 0 {
+		fields = append(fields, f.recallSelect())
+	}
+
+	if coAuthors := f.coAuthorOptions(); len(coAuthors) > 0 {
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title("Co-authors").
+			Description("Press space to toggle and / to search. The selection is kept for the next commits until cleared").
+			Options(coAuthors...).
+			Value(&f.CoAuthors))
+	}
+
+	if err := huh.NewForm(huh.NewGroup(fields...)).WithTheme(settings.HuhTheme).Run(); err != nil {
+		return err
+	}
+
+	f.applyRecalled()
+	return nil
+}
+
+// referenceInput returns the reference field. When an issue tracker is
+// configured, the reference is validated against it, the issue title and
+// status are shown, and assigned issues are suggested while typing.
+func (f *DefaultCommitForm) referenceInput(recent []string) *huh.Input {
+	input := huh.NewInput().Title("Reference").Value(&f.Jira).Placeholder("Jira ticket if any")
+	if f.References == nil {
+		return input.Suggestions(recent)
+	}
+
+	return input.
+		Placeholder(f.References.Hint()+", if any").
+		DescriptionFunc(func() string { return f.References.Describe(f.Jira) }, &f.Jira).
+		SuggestionsFunc(func() []string { return append(slices.Clone(recent), f.References.Suggest(f.Jira)...) }, &f.Jira).
+		Validate(f.References.Validate)
+}
+
+// coAuthorOptions returns the co-author candidates as options, including any
+// selected co-authors that are not candidates anymore.
+func (f *DefaultCommitForm) coAuthorOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, coAuthor := range f.CoAuthors {
+		if !slices.Contains(f.CoAuthorCandidates, coAuthor) {
+			options = append(options, huh.NewOption(coAuthor, coAuthor).Selected(true))
+		}
+	}
+
+	for _, candidate := range f.CoAuthorCandidates {
+		options = append(options, huh.NewOption(candidate, candidate).Selected(slices.Contains(f.CoAuthors, candidate)))
+	}
+
+	return options
+}
+
+// recallSelect returns the field offering the recent commits whose summary
+// fuzzy matches the summary typed so far. The values of the picked commit
+// replace the typed ones once the form is submitted.
+func (f *DefaultCommitForm) recallSelect() *huh.Select[HistoryEntry] {
+	return huh.NewSelect[HistoryEntry]().
+		Title("Recent commits").
+		Description("Matching the summary typed so far. Pick one to reuse its version, type, reference and summary").
+		OptionsFunc(func() []huh.Option[HistoryEntry] {
+			options := []huh.Option[HistoryEntry]{huh.NewOption("Keep the values as typed", HistoryEntry{})}
+			for _, entry := range f.History.MatchSummary(f.Summary) {
+				label := fmt.Sprintf("[%s][%s][%s] %s", entry.Version, entry.CommitType, entry.Jira, strings.ReplaceAll(entry.Summary, "\n", " "))
+				options = append(options, huh.NewOption(label, entry))
+			}
+			return options
+		}, &f.Summary).
+		Value(&f.recalled)
+}
+
+// applyRecalled replaces the form values with the recent commit picked in the
+// form, if any.
+func (f *DefaultCommitForm) applyRecalled() {
+	if f.recalled == (HistoryEntry{}) {
+		return
+	}
+
+	f.Version, f.Jira, f.Summary = f.recalled.Version, f.recalled.Jira, f.recalled.Summary
+	if f.recalled.CommitType != "" {
+		f.CommitType = f.recalled.CommitType
+	}
 }
 
 // SetDefaultValues initializes the commit form fields with the provided values.
@@ -67,6 +171,49 @@ func (f *DefaultCommitForm) SetDefaultValues(commitTypes []string, defaultCommit
 	f.Jira = defaultJiraReference
 }
 
+// SetTypeHint sets the explanation shown under the commit type when it was
+// suggested from the files in the commit.
+func (f *DefaultCommitForm) SetTypeHint(hint string) {
+	f.TypeHint = hint
+}
+
+// SetValues pre-fills every form field, including the summary.
+func (f *DefaultCommitForm) SetValues(version, commitType, jira, summary string) {
+	f.Version, f.CommitType, f.Jira, f.Summary = version, commitType, jira, summary
+}
+
+// SetHistory sets the recent values offered for recall and autocompletion.
+func (f *DefaultCommitForm) SetHistory(history *FormHistory) {
+	f.History = history
+}
+
+// SetCoAuthors sets the co-authors offered in the form and the ones that are
+// already selected.
+func (f *DefaultCommitForm) SetCoAuthors(candidates []string, selected []string) {
+	f.CoAuthorCandidates = candidates
+	f.CoAuthors = selected
+}
+
+// GetCoAuthors returns the selected co-authors.
+func (f *DefaultCommitForm) GetCoAuthors() []string {
+	return f.CoAuthors
+}
+
+// SetTrailers sets the trailers kept from the amended commit.
+func (f *DefaultCommitForm) SetTrailers(trailers []string) {
+	f.Trailers = trailers
+}
+
+// GetTrailers returns the trailers kept from the amended commit.
+func (f *DefaultCommitForm) GetTrailers() []string {
+	return f.Trailers
+}
+
+// SetReferenceLookup sets the issue tracker lookup used for the reference field.
+func (f *DefaultCommitForm) SetReferenceLookup(lookup *ReferenceLookup) {
+	f.References = lookup
+}
+
 // GetValues returns the values of the commit form fields in the order of version, commit type, jira reference, and summary.
 func (f *DefaultCommitForm) GetValues() (string, string, string, string) {
 	return f.Version, f.CommitType, f.Jira, f.Summary
@@ -75,35 +222,94 @@ func (f *DefaultCommitForm) GetValues() (string, string, string, string) {
 // ShowCommitUI displays a user interface for inputting commit details using the provided form.
 // It prompts the user to confirm committing with the generated commit message.
 // If confirmed, it executes the git commit command with the formatted message.
-// Returns true if the commit was successful or confirmed, false otherwise.
-func ShowCommitUI(helper helpers.GitHelper, config *settings.Config, form CommitForm) bool {
+// Returns ErrCommitCanceled if the user cancels, or the git error if the commit fails.
+func ShowCommitUI(helper helpers.GitHelper, config *settings.Config, form CommitForm) error {
+	return runCommitForm(helper, config, form, "Commit changes with following message?\n", commands.GitCommitMessage, true)
+}
+
+// runCommitForm runs the form, asks the user to confirm the formatted message
+// with the given prompt and then executes the commit command, which receives
+// the message, quoted, through its '%s' verb. The signing flags from the config are
+// appended to the command. With guardBranch set, commits to protected branches
+// are redirected to a new feature branch before the message is confirmed.
+func runCommitForm(helper helpers.GitHelper, config *settings.Config, form CommitForm, prompt string, commitCommand string, guardBranch bool) error {
 	if err := form.Run(); err != nil {
-		return false
+		return fmt.Errorf("%w: %w", ErrCommitCanceled, err)
 	}
 
 	version, commitType, jira, summary := form.GetValues()
-	commitMessage := formatCommitMessage(config, version, commitType, jira, summary)
+	commitMessage := ComposeCommitMessage(config, form)
+
+	confirmMessage := prompt + commitMessage
+	if signing := DescribeSigning(helper, config.Signing); signing != "" {
+		confirmMessage += "\n\n" + signing
+	}
 
-	if helper.ShowConfirm("Commit changes with following message?\n" + commitMessage) {
-		_, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitCommitMessage, commitMessage))
-		if err != nil {
-			log.Printf("Failed to commit changes: %v", err)
+	if guardBranch {
+		if err := GuardProtectedBranch(helper, config, version, commitType, jira, summary); err != nil {
+			return err
 		}
-		return true
 	}
 
-	return false
+	if !helper.ShowConfirm(confirmMessage) {
+		return ErrCommitCanceled
+	}
+
+	if _, err := helper.ExecuteCommand(fmt.Sprintf(commitCommand, helpers.EscapeSingleQuotes(commitMessage)) + SigningFlags(config.Signing)); err != nil {
+		return fmt.Errorf("failed to commit changes: %w", DescribeCommitError(err))
+	}
+
+	return nil
+}
+
+// ComposeCommitMessage returns the commit message for the form values: the
+// commit format filled in, followed by one block of the closing, co-author and
+// kept trailers, each written once. Kept trailers come last, so a 'Signed-off-by' trailer stays
+// last and 'git commit --signoff' does not add it again.
+func ComposeCommitMessage(config *settings.Config, form CommitForm) string {
+	var coAuthors []string
+	if coAuthorForm, ok := form.(CoAuthorForm); ok {
+		coAuthors = coAuthorForm.GetCoAuthors()
+	}
+
+	version, commitType, jira, summary := form.GetValues()
+
+	trailers := CoAuthorTrailers(coAuthors)
+	if closing := trackers.ClosingTrailer(config, jira); closing != "" {
+		trailers = append([]string{closing}, trailers...)
+	}
+	if trailerForm, ok := form.(TrailerForm); ok {
+		trailers = appendMissingTrailers(trailers, trailerForm.GetTrailers())
+	}
+	return formatCommitMessage(config, version, commitType, jira, summary, trailers...)
+}
+
+// appendMissingTrailers appends the kept trailers that are not in the list yet,
+// so amending a commit does not repeat e.g. its 'Closes' trailer.
+func appendMissingTrailers(trailers []string, kept []string) []string {
+	for _, trailer := range kept {
+		if !slices.ContainsFunc(trailers, func(existing string) bool { return strings.EqualFold(existing, trailer) }) {
+			trailers = append(trailers, trailer)
+		}
+	}
+	return trailers
 }
 
 // formatCommitMessage takes in the version, commit type, jira reference, and summary as strings and replaces placeholders in the
-// git commit format string with the given values, returning the formatted string.
-func formatCommitMessage(config *settings.Config, version string, commitType string, jiraReference string, summary string) string {
+// git commit format string with the given values, returning the formatted string. The reference can be written as $jira or $ref.
+// Any trailers are appended after a blank line.
+func formatCommitMessage(config *settings.Config, version string, commitType string, jiraReference string, summary string, trailers ...string) string {
 	formattedMessage := config.CommitFormat
 
 	formattedMessage = strings.ReplaceAll(formattedMessage, "$version", version)
 	formattedMessage = strings.ReplaceAll(formattedMessage, "$type", commitType)
 	formattedMessage = strings.ReplaceAll(formattedMessage, "$jira", jiraReference)
+	formattedMessage = strings.ReplaceAll(formattedMessage, "$ref", jiraReference)
 	formattedMessage = strings.ReplaceAll(formattedMessage, "$summary", summary)
 
+	if len(trailers) > 0 {
+		formattedMessage = strings.TrimRight(formattedMessage, "\n") + "\n\n" + strings.Join(trailers, "\n")
+	}
+
 	return formattedMessage
 }
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// Kinds of commits created for an existing commit.
+const (
+	FixupCommit  = "fixup"
+	SquashCommit = "squash"
+)
+
+// PickCommit shows a searchable picker of the given commits and returns the
+// selected commit. It returns false if the user cancels or there are no commits.
+func PickCommit(title string, commits []CommitInfo) (CommitInfo, bool) {
+	options := make([]helpers.SelectOption, len(commits))
+	for i, commit := range commits {
+		options[i] = helpers.SelectOption{Label: commit.Label(), Value: commit.Hash}
+	}
+
+	hash, ok := helpers.ShowSelect(title, options)
+	if !ok {
+		return CommitInfo{}, false
+	}
+
+	for _, commit := range commits {
+		if commit.Hash == hash {
+			return commit, true
+		}
+	}
+
+	return CommitInfo{}, false
+}
+
+// CreateFixupCommit commits the staged changes as a 'fixup!' or 'squash!'
+// commit for the commit with the given hash, signed as configured.
+func CreateFixupCommit(helper helpers.GitHelper, kind string, hash string, signing settings.Signing) error {
+	var cmd string
+	switch kind {
+	case FixupCommit:
+		cmd = fmt.Sprintf(commands.GitCommitFixup, hash)
+	case SquashCommit:
+		cmd = fmt.Sprintf(commands.GitCommitSquash, hash)
+	default:
+		return fmt.Errorf("unknown commit kind %q", kind)
+	}
+
+	cmd += SigningFlags(signing)
+	output, err := helper.ExecuteCommand(cmd)
+	if err != nil {
+		log.Printf("Failed to create %s commit: %v\nCommand: %q\nOutput: %q", kind, err, cmd, output)
+		return DescribeCommitError(err)
+	}
+
+	return nil
+}
+
+// AutosquashBase returns the revision to rebase onto so the fixup commit is
+// folded into the target: the upstream branch when the target is not part of
+// it yet, otherwise the parent of the target, or --root when the target is the
+// root commit. It reports whether the target is already in the upstream, in
+// which case the rebase rewrites pushed commits.
+func AutosquashBase(helper helpers.GitHelper, target CommitInfo) (base string, pushed bool) {
+	if upstream, err := helper.ExecuteCommand(commands.GitUpstream); err == nil {
+		if _, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitIsAncestor, target.Hash, "@{u}")); err != nil {
+			return strings.TrimSpace(upstream), false
+		}
+		pushed = true
+	}
+
+	parent := target.Hash + "~1"
+	if _, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitRevParse, parent)); err != nil {
+		return "--root", pushed
+	}
+
+	return parent, pushed
+}
+
+// AutosquashRebase runs a non-interactive autosquash rebase onto the given base,
+// folding all 'fixup!' and 'squash!' commits into their targets. Both editors
+// are disabled, so 'squash!' commits keep the combined message instead of
+// opening an editor without a terminal.
+func AutosquashRebase(helper helpers.GitHelper, base string) error {
+	cmd := fmt.Sprintf(commands.GitRebaseSquash, base)
+	output, err := helper.ExecuteCommand(cmd)
+	if err != nil {
+		log.Printf("Failed to run autosquash rebase: %v\nCommand: %q\nOutput: %q", err, cmd, output)
+		return err
+	}
+
+	return nil
+}
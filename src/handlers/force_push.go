@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// overwrittenCommitLimit bounds the remote commits listed before a force push.
+const overwrittenCommitLimit = 20
+
+var (
+	// ErrProtectedBranch is returned when a force push targets a protected branch.
+	ErrProtectedBranch = errors.New("force pushing to a protected branch is not allowed")
+
+	// ErrForcePushCanceled is returned when the user declines the force push.
+	ErrForcePushCanceled = errors.New("force push canceled")
+)
+
+// Diverged reports whether the local branch and its upstream both have commits
+// the other does not, e.g. after amending or rebasing pushed commits.
+func (u *Upstream) Diverged() bool {
+	return u.Ahead > 0 && u.Behind > 0
+}
+
+// CanForcePush reports whether the branch may be force pushed to the remote
+// branch. Force pushes to protected branches are always refused.
+func CanForcePush(config *settings.Config, branch string, remoteBranch string) bool {
+	return !IsProtectedBranch(config, branch) && !IsProtectedBranch(config, remoteBranch)
+}
+
+// ForcePushWithLease force pushes the branch to the remote branch after
+// showing the remote commits that will be overwritten and asking for
+// confirmation. The lease is pinned to the remote commit the user saw, so the
+// push fails if someone pushed in the meantime.
+func ForcePushWithLease(helper helpers.GitHelper, config *settings.Config, remote string, branch string, remoteBranch string) error {
+	if !CanForcePush(config, branch, remoteBranch) {
+		return fmt.Errorf("%w: '%s/%s'", ErrProtectedBranch, remote, remoteBranch)
+	}
+
+	if err := FetchRemote(helper, remote); err != nil {
+		log.Printf("Failed to fetch, the lease may be out of date: %v", err)
+	}
+
+	remoteRef := remote + "/" + remoteBranch
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitRevParse, remoteRef))
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", remoteRef, err)
+	}
+	expected := strings.TrimSpace(output)
+
+	overwritten, err := GetCommits(helper, config, "HEAD.."+remoteRef, overwrittenCommitLimit)
+	if err != nil {
+		return err
+	}
+
+	if !helper.ShowConfirm(describeForcePush(remoteRef, overwritten), false) {
+		return ErrForcePushCanceled
+	}
+
+	cmd := fmt.Sprintf(commands.GitForcePushWithLease, remoteBranch, expected, remote, branch, remoteBranch)
+	if output, err := helper.ExecuteCommand(cmd); err != nil {
+		log.Printf("Failed to force push to %s: %v\nCommand: %q\nOutput: %q", remote, err, cmd, output)
+		return err
+	}
+
+	return nil
+}
+
+// describeForcePush returns the confirmation shown before a force push.
+func describeForcePush(remoteRef string, overwritten []CommitInfo) string {
+	if len(overwritten) == 0 {
+		return fmt.Sprintf("Force pushing to '%s' does not overwrite any commits. Do you want to force push?", remoteRef)
+	}
+
+	labels := make([]string, len(overwritten))
+	for i, commit := range overwritten {
+		labels[i] = commit.Label()
+	}
+
+	more := ""
+	if len(overwritten) == overwrittenCommitLimit {
+		more = "\n-> ..."
+	}
+
+	return fmt.Sprintf("Force pushing to '%s' overwrites these commits:\n-> %s%s\nDo you want to force push?", remoteRef, strings.Join(labels, "\n-> "), more)
+}
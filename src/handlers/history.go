@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const (
+	historyDirName     = "git-commit-ui"
+	historyFileName    = "history.json"
+	defaultHistorySize = 20
+)
+
+// HistoryEntry holds the form values of a single commit session.
+type HistoryEntry struct {
+	Version    string `json:"version"`
+	CommitType string `json:"commit_type"`
+	Jira       string `json:"jira"`
+	Summary    string `json:"summary"`
+}
+
+// FormHistory holds the recent form values for a repository, most recent first.
+// It is stored inside the repository's .git directory so every clone keeps its
+// own history.
+type FormHistory struct {
+	Entries   []HistoryEntry `json:"entries"`
+	CoAuthors []string       `json:"co_authors"` // current pair or mob, kept until cleared
+
+	path  string
+	limit int
+}
+
+// HistoryRecaller is implemented by commit forms that can offer previously used
+// values to the user.
+type HistoryRecaller interface {
+	SetHistory(history *FormHistory)
+}
+
+// LoadFormHistory reads the form history of the current repository. It always
+// returns a usable history; when the repository cannot be located or history is
+// disabled in the config, the returned history is empty and is never saved.
+func LoadFormHistory(helper helpers.GitHelper, config *settings.Config) *FormHistory {
+	history := &FormHistory{limit: historyLimit(config)}
+	if history.limit <= 0 {
+		return history
+	}
+
+	output, err := helper.ExecuteCommand(commands.GitDir)
+	if err != nil {
+		return history
+	}
+
+	gitDir := strings.TrimSpace(output)
+	if info, err := os.Stat(gitDir); err != nil || !info.IsDir() {
+		return history
+	}
+
+	history.path = filepath.Join(gitDir, historyDirName, historyFileName)
+
+	data, err := os.ReadFile(history.path)
+	if err != nil {
+		return history
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		history.Entries = nil
+	}
+
+	return history
+}
+
+// Save writes the history back to the repository. It is a no-op for histories
+// that are not attached to a repository.
+func (h *FormHistory) Save() error {
+	if h.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	if err := os.WriteFile(h.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
+
+// Record adds the entry to the top of the history, removing an identical older
+// entry and trimming the history to the configured size.
+func (h *FormHistory) Record(entry HistoryEntry) {
+	entries := []HistoryEntry{entry}
+	for _, existing := range h.Entries {
+		if existing != entry {
+			entries = append(entries, existing)
+		}
+	}
+
+	limit := h.limit
+	if limit == 0 {
+		limit = defaultHistorySize
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	h.Entries = entries
+}
+
+// Last returns the most recent entry, if any.
+func (h *FormHistory) Last() (HistoryEntry, bool) {
+	if len(h.Entries) == 0 {
+		return HistoryEntry{}, false
+	}
+	return h.Entries[0], true
+}
+
+// Defaults returns the version and reference to pre-fill the form with. Values
+// from the last commit session take precedence over the config defaults.
+func (h *FormHistory) Defaults(config *settings.Config) (version string, jira string) {
+	last, ok := h.Last()
+	if !ok {
+		return config.DefaultVersion, config.DefaultJiraReference
+	}
+
+	version = last.Version
+	if version == "" {
+		version = config.DefaultVersion
+	}
+
+	return version, last.Jira
+}
+
+// Versions returns the distinct recent versions, most recent first.
+func (h *FormHistory) Versions() []string {
+	return h.distinct(func(e HistoryEntry) string { return e.Version })
+}
+
+// References returns the distinct recent references, most recent first.
+func (h *FormHistory) References() []string {
+	return h.distinct(func(e HistoryEntry) string { return e.Jira })
+}
+
+// MatchSummary returns the entries whose summary fuzzy matches the query, the
+// best match first. The letters of the query must appear in the summary in
+// order; spaces and case are ignored. An empty query returns every entry, most
+// recent first.
+func (h *FormHistory) MatchSummary(query string) []HistoryEntry {
+	query = strings.ToLower(strings.Join(strings.Fields(query), ""))
+
+	type match struct {
+		entry HistoryEntry
+		score int
+	}
+	var matches []match
+	for _, entry := range h.Entries {
+		if score, ok := fuzzyScore(query, strings.ToLower(entry.Summary)); ok {
+			matches = append(matches, match{entry, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	entries := make([]HistoryEntry, len(matches))
+	for i, match := range matches {
+		entries[i] = match.entry
+	}
+	return entries
+}
+
+// fuzzyScore reports whether the runes of query appear in text in order, and
+// scores the match: runes following each other or starting a word score
+// higher.
+func fuzzyScore(query string, text string) (int, bool) {
+	score, previous := 0, -2
+	runes := []rune(text)
+	position := 0
+	for _, r := range query {
+		for position < len(runes) && runes[position] != r {
+			position++
+		}
+		if position == len(runes) {
+			return 0, false
+		}
+
+		score++
+		if position == previous+1 {
+			score += 2
+		}
+		if position == 0 || !unicode.IsLetter(runes[position-1]) && !unicode.IsDigit(runes[position-1]) {
+			score += 3
+		}
+		previous = position
+		position++
+	}
+	return score, true
+}
+
+// distinct collects the non-empty values returned by field, keeping the first
+// occurrence of each value.
+func (h *FormHistory) distinct(field func(HistoryEntry) string) []string {
+	seen := map[string]bool{}
+	var values []string
+	for _, entry := range h.Entries {
+		value := strings.TrimSpace(field(entry))
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values
+}
+
+// historyLimit returns the number of entries to keep, falling back to the
+// default when the config does not set one.
+func historyLimit(config *settings.Config) int {
+	if config.HistorySize == 0 {
+		return defaultHistorySize
+	}
+	return config.HistorySize
+}
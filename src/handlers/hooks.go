@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// hookMarker identifies hooks written by InstallCommitMsgHook, so they can be
+// replaced without force.
+const hookMarker = "# Installed by git-commit-ui"
+
+// ErrHookExists is returned when a hook not written by this tool is in the
+// way.
+var ErrHookExists = errors.New("a commit-msg hook already exists")
+
+// CommitMsgHook returns the commit-msg hook script that lints each message
+// with the given executable.
+func CommitMsgHook(executable string) string {
+	return fmt.Sprintf("#!/bin/sh\n%s\nexec '%s' lint -message-file \"$1\"\n", hookMarker, strings.ReplaceAll(executable, "'", `'\''`))
+}
+
+// InstallCommitMsgHook writes the commit-msg hook to the repository's hooks
+// directory, honouring core.hooksPath, and returns its path. A hook written by
+// another tool is only replaced when force is set.
+func InstallCommitMsgHook(helper helpers.GitHelper, executable string, force bool) (string, error) {
+	output, err := helper.ExecuteCommand(commands.GitHooksPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the hooks directory: %w", err)
+	}
+
+	dir := strings.TrimSpace(output)
+	hook := filepath.Join(dir, "commit-msg")
+
+	existing, err := os.ReadFile(hook)
+	if err == nil && !force && !strings.Contains(string(existing), hookMarker) {
+		return hook, fmt.Errorf("%w at %s, use -force to replace it", ErrHookExists, hook)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return hook, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(hook, []byte(CommitMsgHook(executable)), 0755); err != nil {
+		return hook, fmt.Errorf("failed to write %s: %w", hook, err)
+	}
+
+	return hook, nil
+}
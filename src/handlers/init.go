@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -9,16 +10,26 @@ import (
 	"github.com/kurianvarkey/gitcommitui/src/helpers"
 )
 
+// ErrNotGitRepository is returned when the current directory is not a Git
+// repository and none was initialised.
+var ErrNotGitRepository = errors.New("not a git repository")
+
 // CheckForGitInitialise checks whether the current directory is a Git repository, and if
-// not, prompts the user to initialise a repository and continues.
-func CheckForGitInitialise(helper helpers.GitHelper) (exit bool) {
+// not, prompts the user to initialise a repository and continues. It returns
+// ErrNotGitRepository if the user declines, or the error of a scripted run
+// that may not initialise one on its own.
+func CheckForGitInitialise(helper helpers.GitHelper) error {
 	if !isGitInitialised(helper) {
-		if !helper.ShowConfirm("Git repository not found. Do you want to initialise and continue?", true) {
-			return true
+		title := "Git repository not found. Do you want to initialise and continue?"
+		if err := helpers.RequireConfirmation(title); err != nil {
+			return err
+		}
+		if !helper.ShowConfirm(title, true) {
+			return ErrNotGitRepository
 		}
 		initialiseGit(helper)
 	}
-	return false
+	return nil
 }
 
 // IsGitInitialised checks whether the current directory is a Git repository.
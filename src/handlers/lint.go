@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// generatedMessagePrefixes start messages written by git itself, which are
+// not linted.
+var generatedMessagePrefixes = []string{"Merge ", "fixup! ", "squash! ", "amend! "}
+
+// LintResult is a commit whose message has problems.
+type LintResult struct {
+	Commit   CommitInfo
+	Problems []string
+}
+
+// LintMessage returns the problems with a commit message: not matching the
+// commit format, a type that is not one of the commit types or an empty
+// summary. Comment lines starting with '#' are ignored, as git strips them, and
+// messages generated by git, such as merges and fixups, have no problems.
+func LintMessage(config *settings.Config, message string) []string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	message = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	if message == "" {
+		return []string{"the message is empty"}
+	}
+	for _, prefix := range generatedMessagePrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return nil
+		}
+	}
+
+	parsed, ok := ParseCommitMessage(config, message)
+	if !ok {
+		return []string{fmt.Sprintf("the message does not match the commit format %q", config.CommitFormat)}
+	}
+
+	var problems []string
+	if strings.Contains(config.CommitFormat, "$type") && len(config.CommitTypes) > 0 && !slices.Contains(config.CommitTypes, parsed.CommitType) {
+		problems = append(problems, fmt.Sprintf("the type %q is not one of: %s", parsed.CommitType, strings.Join(config.CommitTypes, ", ")))
+	}
+	if parsed.Summary == "" {
+		problems = append(problems, "the summary is empty")
+	}
+	return problems
+}
+
+// LintCommits lints the messages of up to limit commits in the revision range
+// and returns the commits with problems, newest first, and the number of
+// commits read.
+func LintCommits(helper helpers.GitHelper, config *settings.Config, revisionRange string, limit int) ([]LintResult, int, error) {
+	commits, err := GetCommits(helper, config, revisionRange, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []LintResult
+	for _, commit := range commits {
+		if problems := LintMessage(config, commit.Message); len(problems) > 0 {
+			results = append(results, LintResult{Commit: commit, Problems: problems})
+		}
+	}
+	return results, len(commits), nil
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// CommitInfo describes a commit read from the history, together with the form
+// values parsed from its message.
+type CommitInfo struct {
+	Hash, ShortHash     string
+	Author, AuthorEmail string
+	Date                time.Time
+	Message             string
+	Parsed              ParsedCommit
+	Conforming          bool // true when the message matches the commit format
+}
+
+// Subject returns the first line of the commit message.
+func (c CommitInfo) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// Label returns a one line description of the commit suitable for pickers and
+// lists, using the parsed values when the message matches the commit format.
+func (c CommitInfo) Label() string {
+	if !c.Conforming {
+		return fmt.Sprintf("%s %s", c.ShortHash, c.Subject())
+	}
+
+	summary, _, _ := strings.Cut(c.Parsed.Summary, "\n")
+	return fmt.Sprintf("%s [%s][%s] %s", c.ShortHash, c.Parsed.CommitType, c.Parsed.Jira, strings.TrimSpace(summary))
+}
+
+// GetCommits reads up to limit commits from the given revision range, newest
+// first, and parses each message through the commit format. An empty range
+// reads the history of HEAD.
+func GetCommits(helper helpers.GitHelper, config *settings.Config, revisionRange string, limit int) ([]CommitInfo, error) {
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitLog, limit, revisionRange))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit history: %w", err)
+	}
+
+	return parseCommitLog(config, output), nil
+}
+
+// parseCommitLog parses the output of commands.GitLog.
+func parseCommitLog(config *settings.Config, output string) []CommitInfo {
+	var commits []CommitInfo
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\r\n"), "\x1f")
+		if len(fields) < 6 || strings.TrimSpace(fields[0]) == "" {
+			continue
+		}
+
+		commit := CommitInfo{
+			Hash:        strings.TrimSpace(fields[0]),
+			ShortHash:   strings.TrimSpace(fields[1]),
+			Author:      fields[2],
+			AuthorEmail: fields[3],
+			Message:     strings.TrimSpace(fields[5]),
+		}
+		commit.Date, _ = time.Parse(time.RFC3339, strings.TrimSpace(fields[4]))
+		commit.Parsed, commit.Conforming = ParseCommitMessage(config, commit.Message)
+
+		commits = append(commits, commit)
+	}
+
+	return commits
+}
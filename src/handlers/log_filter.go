@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// LogFilter narrows the commit history by the parsed commit fields. Empty
+// fields match every commit. Type, reference, version and author match
+// case-insensitively; the text is searched in the whole message.
+type LogFilter struct {
+	Type      string
+	Reference string
+	Version   string
+	Author    string // name or email
+	Since     time.Time
+	Until     time.Time // inclusive, the whole day
+	Text      string
+}
+
+// IsEmpty reports whether the filter matches every commit.
+func (f LogFilter) IsEmpty() bool {
+	return f == LogFilter{}
+}
+
+// Matches reports whether the commit passes the filter. Commits that do not
+// match the commit format have no type, reference or version, so they are
+// left out when any of those is filtered on.
+func (f LogFilter) Matches(commit CommitInfo) bool {
+	if f.Type != "" && !strings.EqualFold(commit.Parsed.CommitType, f.Type) {
+		return false
+	}
+	if f.Reference != "" && !strings.EqualFold(commit.Parsed.Jira, f.Reference) {
+		return false
+	}
+	if f.Version != "" && !strings.EqualFold(commit.Parsed.Version, f.Version) {
+		return false
+	}
+	if f.Author != "" && !containsFold(commit.Author, f.Author) && !containsFold(commit.AuthorEmail, f.Author) {
+		return false
+	}
+	if !f.Since.IsZero() && commit.Date.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !commit.Date.Before(f.Until.AddDate(0, 0, 1)) {
+		return false
+	}
+	if f.Text != "" && !containsFold(commit.Message, f.Text) {
+		return false
+	}
+	return true
+}
+
+// Describe returns a short description of the active filters, e.g.
+// "type=feat, author=jane".
+func (f LogFilter) Describe() string {
+	var parts []string
+	add := func(name string, value string) {
+		if value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+
+	add("type", f.Type)
+	add("ref", f.Reference)
+	add("version", f.Version)
+	add("author", f.Author)
+	if !f.Since.IsZero() {
+		add("since", f.Since.Format(time.DateOnly))
+	}
+	if !f.Until.IsZero() {
+		add("until", f.Until.Format(time.DateOnly))
+	}
+	if f.Text != "" {
+		add("text", fmt.Sprintf("%q", f.Text))
+	}
+
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FilterCommits returns the commits that pass the filter, keeping their order.
+func FilterCommits(commits []CommitInfo, filter LogFilter) []CommitInfo {
+	var filtered []CommitInfo
+	for _, commit := range commits {
+		if filter.Matches(commit) {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
+
+// ParseFilterDate parses a date in YYYY-MM-DD format in local time. An empty
+// value returns the zero time.
+func ParseFilterDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// CommitDetails returns the full commit with its message, files and diff.
+func CommitDetails(helper helpers.GitHelper, hash string) (string, error) {
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitShowCommit, hash))
+	if err != nil {
+		return "", fmt.Errorf("failed to show commit %s: %w", hash, err)
+	}
+	return output, nil
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s string, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// ParsedCommit holds the form values recovered from a commit message.
+type ParsedCommit struct {
+	Version, CommitType, Jira, Summary string
+	CoAuthors                          []string
+	Trailers                           []string // trailers other than the co-authors, e.g. "Signed-off-by: ..."
+}
+
+var formatPlaceholders = []string{"$version", "$type", "$jira", "$ref", "$summary"}
+
+// ParseCommitMessage reverse-parses a commit message through the configured
+// commit format, recovering the version, commit type, jira reference and
+// summary. The trailer block is split off: Co-authored-by trailers into
+// CoAuthors and the other trailers into Trailers. It returns
+// false when the message does not match the format.
+func ParseCommitMessage(config *settings.Config, message string) (ParsedCommit, bool) {
+	pattern, err := commitFormatPattern(config.CommitFormat)
+	if err != nil {
+		return ParsedCommit{}, false
+	}
+
+	message, coAuthors, trailers := splitTrailers(strings.TrimSpace(message))
+
+	match := pattern.FindStringSubmatch(message)
+	if match == nil {
+		return ParsedCommit{}, false
+	}
+
+	parsed := ParsedCommit{CoAuthors: coAuthors, Trailers: trailers}
+	for i, name := range pattern.SubexpNames() {
+		switch name {
+		case "version":
+			parsed.Version = strings.TrimSpace(match[i])
+		case "type":
+			parsed.CommitType = strings.TrimSpace(match[i])
+		case "jira":
+			parsed.Jira = strings.TrimSpace(match[i])
+		case "summary":
+			parsed.Summary = strings.TrimSpace(match[i])
+		}
+	}
+
+	return parsed, true
+}
+
+// commitFormatPattern turns a commit format such as "[$version][$type][$jira]: $summary"
+// into a regular expression with a named group for each placeholder. Literal
+// text is matched exactly, the summary matches the rest of the message and
+// repeated placeholders are only captured once.
+func commitFormatPattern(format string) (*regexp.Regexp, error) {
+	var builder strings.Builder
+	builder.WriteString(`(?s)^`)
+
+	captured := map[string]bool{}
+	for rest := format; rest != ""; {
+		index, placeholder := nextPlaceholder(rest)
+		if index < 0 {
+			builder.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+
+		builder.WriteString(regexp.QuoteMeta(rest[:index]))
+		name := strings.TrimPrefix(placeholder, "$")
+		if name == "ref" {
+			name = "jira" // $ref is an alias of $jira
+		}
+
+		switch {
+		case captured[name]:
+			builder.WriteString(`.*?`)
+		case name == "summary":
+			builder.WriteString(`(?P<summary>.*)`)
+		default:
+			builder.WriteString(`(?P<` + name + `>.*?)`)
+		}
+
+		captured[name] = true
+		rest = rest[index+len(placeholder):]
+	}
+
+	builder.WriteString(`$`)
+
+	return regexp.Compile(builder.String())
+}
+
+// nextPlaceholder finds the first placeholder in the format, returning its
+// position and name, or -1 when there are no placeholders left.
+func nextPlaceholder(format string) (int, string) {
+	index, found := -1, ""
+	for _, placeholder := range formatPlaceholders {
+		if i := strings.Index(format, placeholder); i >= 0 && (index < 0 || i < index) {
+			index, found = i, placeholder
+		}
+	}
+	return index, found
+}
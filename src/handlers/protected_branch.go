@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const (
+	defaultBranchTemplate = "$type/$jira-$summary-slug"
+	maxSlugLength         = 50
+)
+
+var (
+	// invalidRefCharacters matches characters git does not allow in branch
+	// names, and whitespace.
+	invalidRefCharacters = regexp.MustCompile(`[\s~^:?*\[\\]+|\.\.|@\{`)
+	nonSlugCharacters    = regexp.MustCompile(`[^a-z0-9]+`)
+	repeatedSeparators   = regexp.MustCompile(`-{2,}|-*/-*|/{2,}`)
+)
+
+// IsProtectedBranch reports whether the branch matches one of the protected
+// branch globs in the config. Globs are matched against the whole branch name,
+// so "main" does not protect "feat/main".
+func IsProtectedBranch(config *settings.Config, branch string) bool {
+	for _, pattern := range config.ProtectedBranches {
+		if strings.Contains(pattern, "/") {
+			if helpers.MatchGlob(pattern, branch) {
+				return true
+			}
+			continue
+		}
+
+		if matched, err := path.Match(strings.TrimSpace(pattern), branch); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FeatureBranchName fills the branch template from the config with the form
+// values. "$summary-slug" is the summary in lower case with words joined by
+// dashes, and empty values leave no stray separators behind.
+func FeatureBranchName(config *settings.Config, version, commitType, jira, summary string) string {
+	template := config.BranchTemplate
+	if template == "" {
+		template = defaultBranchTemplate
+	}
+
+	name := strings.NewReplacer(
+		"$summary-slug", slugify(summary),
+		"$version", refSafe(version),
+		"$type", refSafe(commitType),
+		"$jira", refSafe(jira),
+		"$ref", refSafe(jira),
+		"$summary", refSafe(summary),
+	).Replace(template)
+
+	name = repeatedSeparators.ReplaceAllStringFunc(name, func(separator string) string {
+		if strings.Contains(separator, "/") {
+			return "/"
+		}
+		return "-"
+	})
+
+	name = strings.Trim(name, "-/.")
+	return strings.TrimSuffix(name, ".lock")
+}
+
+// GuardProtectedBranch blocks commits to a protected branch. When the current
+// branch is protected, it offers to create a feature branch named from the
+// form values and switches to it, keeping the staged changes. It returns an
+// error when the commit must not go ahead, ErrCommitCanceled if the user
+// declines.
+func GuardProtectedBranch(helper helpers.GitHelper, config *settings.Config, version, commitType, jira, summary string) error {
+	if len(config.ProtectedBranches) == 0 {
+		return nil
+	}
+
+	branch, err := GetCurrentBranch(helper)
+	if err != nil || !IsProtectedBranch(config, branch) {
+		return nil
+	}
+
+	name := FeatureBranchName(config, version, commitType, jira, summary)
+	if name == "" {
+		return fmt.Errorf("committing to the protected branch '%s' is blocked and no branch name could be made from the form values", branch)
+	}
+
+	title := fmt.Sprintf("'%s' is a protected branch and cannot be committed to directly. Do you want to create the branch '%s' and commit there?", branch, name)
+	if err := helpers.RequireConfirmation(title); err != nil {
+		return err
+	}
+	if !helper.ShowConfirm(title, true) {
+		return ErrCommitCanceled
+	}
+
+	if output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitCreateBranch, name)); err != nil {
+		log.Printf("Failed to create branch '%s': %v\nOutput: %q", name, err, output)
+		return fmt.Errorf("failed to create branch '%s': %w", name, err)
+	}
+
+	log.Printf("Switched to a new branch '%s'", name)
+	return nil
+}
+
+// slugify turns text into lower case words joined by dashes, cut to a length
+// that keeps branch names readable.
+func slugify(text string) string {
+	slug := strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+// refSafe replaces the characters that are not allowed in branch names.
+func refSafe(value string) string {
+	return invalidRefCharacters.ReplaceAllString(strings.TrimSpace(value), "-")
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/forges"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// pullRequestCommitLimit bounds the commits read for the pull request text.
+const pullRequestCommitLimit = 50
+
+// PullRequestText builds the title and description of a pull request from the
+// commits on the branch, given newest first. The title is the subject of the
+// oldest commit and the description lists every commit subject, oldest first.
+func PullRequestText(commits []CommitInfo) (title string, body string) {
+	if len(commits) == 0 {
+		return "", ""
+	}
+
+	title = commits[len(commits)-1].Subject()
+	if len(commits) == 1 {
+		_, rest, _ := strings.Cut(commits[0].Message, "\n")
+		return title, strings.TrimSpace(rest)
+	}
+
+	var lines []string
+	for i := len(commits) - 1; i >= 0; i-- {
+		lines = append(lines, "- "+commits[i].Subject())
+	}
+
+	return title, strings.Join(lines, "\n")
+}
+
+// OpenPullRequest finds the open pull/merge request for the branch on the
+// forge, or creates one from the commits on the branch that are not on the
+// base branch of the given remote yet.
+func OpenPullRequest(ctx context.Context, helper helpers.GitHelper, config *settings.Config, forge forges.Forge, remote string, branch string) (*forges.PullRequest, error) {
+	base := config.PullRequest.Base
+	if base == "" {
+		defaultBranch, err := forge.DefaultBranch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine the default branch: %w", err)
+		}
+		base = defaultBranch
+	}
+
+	if base == branch {
+		return nil, fmt.Errorf("branch '%s' is the base branch", branch)
+	}
+
+	commits, err := GetCommits(helper, config, fmt.Sprintf("%s/%s..HEAD", remote, base), pullRequestCommitLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	title, body := PullRequestText(commits)
+	if title == "" {
+		title = branch
+	}
+
+	return forge.FindOrCreatePullRequest(ctx, forges.PullRequestOptions{
+		Head:      branch,
+		Base:      base,
+		Title:     title,
+		Body:      body,
+		Reviewers: config.PullRequest.Reviewers,
+		Labels:    config.PullRequest.Labels,
+		Draft:     config.PullRequest.Draft,
+	})
+}
@@ -3,22 +3,169 @@ package handlers
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/kurianvarkey/gitcommitui/src/commands"
 	"github.com/kurianvarkey/gitcommitui/src/helpers"
 )
 
-// pushToOrigin executes the Git command to push the current branch to the remote
-// repository on the branch with the given name.
-//
-// If the command fails, an error is logged with the command and output.
-func PushToOrigin(helper helpers.GitHelper, remoteBranch string) error {
-	cmd := fmt.Sprintf(commands.GitPush, remoteBranch)
+const defaultRemote = "origin"
+
+// Remote is a configured git remote.
+type Remote struct {
+	Name string
+	URL  string
+}
+
+// PushResult is the outcome of pushing to a single remote.
+type PushResult struct {
+	Remote Remote
+	Err    error
+}
+
+// PushToRemote pushes the current branch to the branch with the given name on
+// the remote and sets it as the upstream. If the command fails, an error is logged with the command and
+// output.
+func PushToRemote(helper helpers.GitHelper, remote string, remoteBranch string) error {
+	cmd := fmt.Sprintf(commands.GitPush, remote, remoteBranch)
 	output, err := helper.ExecuteCommand(cmd)
 	if err != nil {
-		log.Printf("Failed to push to origin: %v\nCommand: %q\nOutput: %q", err, cmd, output)
+		log.Printf("Failed to push to %s: %v\nCommand: %q\nOutput: %q", remote, err, cmd, output)
 		return err
 	}
 
 	return nil
 }
+
+// PushToRemotes pushes the branch to every remote in turn and returns the
+// result for each of them. A failed push does not stop the others. The
+// upstream remote receives the branch under the upstream's branch name. When
+// the branch has no upstream yet, the push sets it with '-u'.
+func PushToRemotes(helper helpers.GitHelper, remotes []Remote, branch string, upstream *Upstream) []PushResult {
+	results := make([]PushResult, 0, len(remotes))
+	for _, remote := range remotes {
+		results = append(results, PushResult{Remote: remote, Err: pushBranch(helper, remote.Name, branch, upstream)})
+	}
+	return results
+}
+
+// pushBranch pushes the branch to one remote, see PushToRemotes.
+func pushBranch(helper helpers.GitHelper, remote string, branch string, upstream *Upstream) error {
+	if upstream == nil {
+		return PushToRemote(helper, remote, branch)
+	}
+
+	remoteBranch := branch
+	if remote == upstream.Remote {
+		remoteBranch = upstream.Branch
+	}
+
+	cmd := fmt.Sprintf(commands.GitPushRefspec, remote, branch, remoteBranch)
+	output, err := helper.ExecuteCommand(cmd)
+	if err != nil {
+		log.Printf("Failed to push to %s: %v\nCommand: %q\nOutput: %q", remote, err, cmd, output)
+		return err
+	}
+
+	return nil
+}
+
+// PushSummary describes the push results, one line per remote.
+func PushSummary(results []PushResult) string {
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			lines = append(lines, fmt.Sprintf("✗ %s (%s): %v", result.Remote.Name, result.Remote.URL, result.Err))
+		} else {
+			lines = append(lines, fmt.Sprintf("✓ %s (%s)", result.Remote.Name, result.Remote.URL))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GetRemotes returns the configured remotes with their fetch URLs, in the
+// order git lists them.
+func GetRemotes(helper helpers.GitHelper) ([]Remote, error) {
+	output, err := helper.ExecuteCommand(commands.GitRemotes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	var remotes []Remote
+	for _, line := range strings.Split(output, "\n") {
+		// Each line looks like "origin\tgit@github.com:owner/repo.git (fetch)"
+		name, rest, found := strings.Cut(strings.TrimSpace(line), "\t")
+		url, kind, _ := strings.Cut(rest, " ")
+		if !found || kind != "(fetch)" {
+			continue
+		}
+		remotes = append(remotes, Remote{Name: name, URL: url})
+	}
+
+	return remotes, nil
+}
+
+// TrackingRemote returns the remote to push the branch to by default: the
+// remote of the branch's tracking branch, then "origin", then the first
+// remote. It returns an empty string when there are no remotes.
+func TrackingRemote(helper helpers.GitHelper, branch string, remotes []Remote) string {
+	names := map[string]bool{}
+	for _, remote := range remotes {
+		names[remote.Name] = true
+	}
+
+	if remote := gitConfigValue(helper, fmt.Sprintf("branch.%s.remote", branch)); names[remote] {
+		return remote
+	}
+
+	if names[defaultRemote] {
+		return defaultRemote
+	}
+
+	if len(remotes) > 0 {
+		return remotes[0].Name
+	}
+
+	return ""
+}
+
+// SelectPushRemotes asks which remotes to push the branch to. With a single
+// remote it asks for confirmation, otherwise it shows a multi-select with the
+// tracking remote preselected. It returns false when the user declines or
+// selects nothing.
+func SelectPushRemotes(helper helpers.GitHelper, branch string, remotes []Remote) ([]Remote, bool) {
+	if len(remotes) == 0 {
+		return nil, false
+	}
+
+	if len(remotes) == 1 {
+		if !helper.ShowConfirm(fmt.Sprintf("Do you want to push the current branch '%s' to %s?", branch, remotes[0].Name), true) {
+			return nil, false
+		}
+		return remotes, true
+	}
+
+	options := make([]helpers.SelectOption, len(remotes))
+	for i, remote := range remotes {
+		options[i] = helpers.SelectOption{Label: fmt.Sprintf("%s (%s)", remote.Name, remote.URL), Value: remote.Name}
+	}
+
+	names, ok := helpers.ShowMultiSelect(fmt.Sprintf("Push the current branch '%s' to", branch), options, []string{TrackingRemote(helper, branch, remotes)})
+	if !ok || len(names) == 0 {
+		return nil, false
+	}
+
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	var chosen []Remote
+	for _, remote := range remotes {
+		if selected[remote.Name] {
+			chosen = append(chosen, remote)
+		}
+	}
+
+	return chosen, true
+}
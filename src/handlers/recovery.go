@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const (
+	recoveryContinue = "continue"
+	recoveryAbort    = "abort"
+	recoveryCancel   = "cancel"
+	recoveryForce    = "force"
+)
+
+// ErrRecoveryAborted is returned when the user aborts or cancels the recovery
+// of a rejected push. The branch is left as it was before the pull.
+var ErrRecoveryAborted = errors.New("recovery aborted")
+
+// rejectedPushFragments are fragments of git's output when a push is rejected
+// because the remote branch has commits the local branch does not have.
+var rejectedPushFragments = []string{
+	"non-fast-forward",
+	"fetch first",
+	"updates were rejected because the tip of your current branch is behind",
+	"updates were rejected because the remote contains work",
+}
+
+// IsRejectedPush reports whether the push failed because the remote branch
+// moved on and the local branch has to be updated first.
+func IsRejectedPush(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range rejectedPushFragments {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RecoverRejectedPush walks the user through updating the branch after a
+// rejected push: it asks whether to pull with rebase or merge, offering the
+// configured strategy first, pulls the remote branch and guides the user
+// through any conflicts. When the history was rewritten, e.g. by amending, so
+// the branch and the remote branch have diverged, it also offers a force push
+// with lease unless the branch is protected. It
+// returns true when the branch was force pushed; otherwise a nil error means
+// the push can be retried.
+func RecoverRejectedPush(helper helpers.GitHelper, config *settings.Config, out io.Writer, remote string, branch string, remoteBranch string) (forced bool, err error) {
+	rebase := helpers.SelectOption{Label: "Pull with rebase and push again", Value: settings.PullRebase}
+	merge := helpers.SelectOption{Label: "Pull with merge and push again", Value: settings.PullMerge}
+
+	options := []helpers.SelectOption{rebase, merge}
+	if config.Push.PullStrategy == settings.PullMerge {
+		options = []helpers.SelectOption{merge, rebase}
+	}
+	if CanForcePush(config, branch, remoteBranch) && remoteDiverged(helper, remote, remoteBranch) {
+		options = append(options, helpers.SelectOption{Label: "Force push with lease, overwriting the remote commits", Value: recoveryForce})
+	}
+	options = append(options, helpers.SelectOption{Label: "Cancel", Value: recoveryCancel})
+
+	title := fmt.Sprintf("The push to %s was rejected because '%s/%s' has commits you do not have locally. How do you want to update the branch?", remote, remote, remoteBranch)
+	strategy, ok := helpers.ShowSelect(title, options)
+	if !ok || strategy == recoveryCancel {
+		return false, ErrRecoveryAborted
+	}
+
+	if strategy == recoveryForce {
+		if err := ForcePushWithLease(helper, config, remote, branch, remoteBranch); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	pullCommand := commands.GitPullRebase
+	if strategy == settings.PullMerge {
+		pullCommand = commands.GitPullMerge
+	}
+
+	cmd := fmt.Sprintf(pullCommand, remote, remoteBranch)
+	output, err := helper.ExecuteCommand(cmd)
+	if err == nil {
+		return false, nil
+	}
+
+	conflicts, conflictsErr := ConflictedFiles(helper)
+	if conflictsErr != nil || len(conflicts) == 0 {
+		log.Printf("Failed to pull: %v\nCommand: %q\nOutput: %q", err, cmd, output)
+		return false, fmt.Errorf("failed to pull %s/%s: %w", remote, remoteBranch, err)
+	}
+
+	return false, ResolveConflicts(helper, out, strategy, conflicts)
+}
+
+// remoteDiverged fetches the remote and reports whether the branch and the
+// remote branch both have commits the other does not have, so neither can be
+// fast-forwarded to the other.
+func remoteDiverged(helper helpers.GitHelper, remote string, remoteBranch string) bool {
+	if err := FetchRemote(helper, remote); err != nil {
+		log.Printf("Failed to fetch, not offering a force push: %v", err)
+		return false
+	}
+
+	upstream := &Upstream{Remote: remote, Branch: remoteBranch}
+	if err := UpdateAheadBehind(helper, upstream); err != nil {
+		log.Printf("Not offering a force push: %v", err)
+		return false
+	}
+	return upstream.Diverged()
+}
+
+// ResolveConflicts lists the conflicted files and lets the user continue once
+// they are resolved, or abort. Continuing stages the files and continues the
+// rebase or merge; a rebase that stops on the next commit shows the new
+// conflicts. Files that still contain conflict markers are not staged.
+func ResolveConflicts(helper helpers.GitHelper, out io.Writer, strategy string, conflicts []string) error {
+	continueCommand, abortCommand := commands.GitRebaseContinue, commands.GitRebaseAbort
+	if strategy == settings.PullMerge {
+		continueCommand, abortCommand = commands.GitMergeContinue, commands.GitMergeAbort
+	}
+
+	options := []helpers.SelectOption{
+		{Label: "Continue, the conflicts are resolved", Value: recoveryContinue},
+		{Label: fmt.Sprintf("Abort the %s", strategy), Value: recoveryAbort},
+	}
+
+	for len(conflicts) > 0 {
+		title := fmt.Sprintf("Conflicts in %d files. Resolve them in your editor, then continue.\n-> %s", len(conflicts), strings.Join(conflicts, "\n-> "))
+		choice, ok := helpers.ShowSelect(title, options)
+		if !ok || choice == recoveryAbort {
+			if _, err := helper.ExecuteCommand(abortCommand); err != nil {
+				return fmt.Errorf("failed to abort the %s: %w", strategy, err)
+			}
+			return ErrRecoveryAborted
+		}
+
+		if unresolved := filesWithConflictMarkers(helper, conflicts); len(unresolved) > 0 {
+			fmt.Fprintf(out, "These files still contain conflict markers:\n-> %s\n", strings.Join(unresolved, "\n-> "))
+			continue
+		}
+
+		if _, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitAddFiles, quoteFiles(conflicts))); err != nil {
+			return fmt.Errorf("failed to stage the resolved files: %w", err)
+		}
+
+		_, continueErr := helper.ExecuteCommand(continueCommand)
+
+		var err error
+		conflicts, err = ConflictedFiles(helper)
+		if err != nil {
+			return err
+		}
+		if continueErr != nil && len(conflicts) == 0 {
+			if _, err := helper.ExecuteCommand(abortCommand); err != nil {
+				log.Printf("Failed to abort the %s: %v", strategy, err)
+			}
+			return fmt.Errorf("failed to continue the %s, it was aborted: %w", strategy, continueErr)
+		}
+	}
+
+	return nil
+}
+
+// ConflictedFiles returns the files with unresolved conflicts.
+func ConflictedFiles(helper helpers.GitHelper) ([]string, error) {
+	output, err := helper.ExecuteCommand(commands.GitConflictedFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if file := strings.TrimSpace(line); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// filesWithConflictMarkers returns the files that still contain conflict
+// markers. Files that cannot be read, e.g. because they were deleted, are
+// treated as resolved.
+func filesWithConflictMarkers(helper helpers.GitHelper, files []string) []string {
+	output, err := helper.ExecuteCommand(commands.GitTopLevel)
+	if err != nil {
+		return nil
+	}
+	root := strings.TrimSpace(output)
+
+	var unresolved []string
+	for _, file := range files {
+		if hasConflictMarkers(filepath.Join(root, file)) {
+			unresolved = append(unresolved, file)
+		}
+	}
+	return unresolved
+}
+
+// hasConflictMarkers reports whether the file contains a line starting with a
+// conflict marker.
+func hasConflictMarkers(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteFiles quotes the file names for a command run through
+// helpers.ParseCommand, so names with spaces or apostrophes stay intact.
+func quoteFiles(files []string) string {
+	quoted := make([]string, len(files))
+	for i, file := range files {
+		quoted[i] = "'" + helpers.EscapeSingleQuotes(file) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
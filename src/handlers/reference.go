@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+)
+
+// ReferenceLookupSetter is implemented by commit forms that can validate the
+// reference against an issue tracker.
+type ReferenceLookupSetter interface {
+	SetReferenceLookup(lookup *ReferenceLookup)
+}
+
+// ReferenceLookup validates, describes and suggests references using an issue
+// tracker. Results are cached for the lifetime of the lookup, so typing in the
+// form does not repeat requests. Once the tracker is found to be unavailable
+// no further requests are made. It is safe for concurrent use.
+type ReferenceLookup struct {
+	tracker trackers.Tracker
+
+	mu       sync.Mutex
+	offline  bool
+	issues   map[string]issueResult
+	searches map[string][]trackers.Issue
+}
+
+// issueResult is a cached GetIssue result.
+type issueResult struct {
+	issue *trackers.Issue
+	err   error
+}
+
+// NewReferenceLookup returns a lookup for the tracker, or nil when no tracker
+// is configured.
+func NewReferenceLookup(tracker trackers.Tracker) *ReferenceLookup {
+	if tracker == nil {
+		return nil
+	}
+
+	return &ReferenceLookup{
+		tracker:  tracker,
+		issues:   map[string]issueResult{},
+		searches: map[string][]trackers.Issue{},
+	}
+}
+
+// Hint describes the reference syntax of the tracker.
+func (l *ReferenceLookup) Hint() string {
+	return l.tracker.ReferenceHint()
+}
+
+// Validate returns an error when the reference does not have the tracker's
+// syntax or does not exist in the tracker. Empty references are allowed, and
+// references that cannot be checked because the tracker is unavailable are
+// accepted.
+func (l *ReferenceLookup) Validate(reference string) error {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return nil
+	}
+
+	if !l.tracker.ValidReference(reference) {
+		return fmt.Errorf("%s is not a valid reference, expected a %s", reference, l.tracker.ReferenceHint())
+	}
+
+	if _, err := l.getIssue(reference); errors.Is(err, trackers.ErrIssueNotFound) {
+		return fmt.Errorf("issue %s was not found", reference)
+	}
+
+	return nil
+}
+
+// Describe returns the title and status of the referenced issue, or a note
+// explaining why it could not be shown.
+func (l *ReferenceLookup) Describe(reference string) string {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return "Type to search issues assigned to you"
+	}
+
+	if !l.tracker.ValidReference(reference) {
+		return "Expected a " + l.tracker.ReferenceHint()
+	}
+
+	issue, err := l.getIssue(reference)
+	switch {
+	case err == nil:
+		return fmt.Sprintf("%s [%s]", issue.Title, issue.Status)
+	case errors.Is(err, trackers.ErrIssueNotFound):
+		return "Issue not found in " + l.tracker.Name()
+	default:
+		return l.tracker.Name() + " unavailable, the reference is not verified"
+	}
+}
+
+// Suggest returns the keys of the assigned issues matching the text. It
+// returns nothing when the tracker is unavailable.
+func (l *ReferenceLookup) Suggest(text string) []string {
+	text = strings.TrimSpace(text)
+
+	l.mu.Lock()
+	issues, cached := l.searches[text]
+	offline := l.offline
+	l.mu.Unlock()
+
+	if offline {
+		return nil
+	}
+
+	if !cached {
+		ctx, cancel := trackers.WithTimeout(context.Background())
+		defer cancel()
+
+		var err error
+		issues, err = l.tracker.SearchIssues(ctx, text)
+		l.store(func() {
+			if errors.Is(err, trackers.ErrUnavailable) {
+				l.offline = true
+			} else if err == nil {
+				l.searches[text] = issues
+			}
+		})
+		if err != nil {
+			return nil
+		}
+	}
+
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+
+	return keys
+}
+
+// getIssue returns the issue for the reference, using the cache when possible.
+func (l *ReferenceLookup) getIssue(reference string) (*trackers.Issue, error) {
+	l.mu.Lock()
+	result, cached := l.issues[reference]
+	offline := l.offline
+	l.mu.Unlock()
+
+	if cached {
+		return result.issue, result.err
+	}
+	if offline {
+		return nil, trackers.ErrUnavailable
+	}
+
+	ctx, cancel := trackers.WithTimeout(context.Background())
+	defer cancel()
+
+	issue, err := l.tracker.GetIssue(ctx, reference)
+	l.store(func() {
+		if errors.Is(err, trackers.ErrUnavailable) {
+			l.offline = true
+		} else {
+			l.issues[reference] = issueResult{issue: issue, err: err}
+		}
+	})
+
+	return issue, err
+}
+
+// store runs update while holding the lock.
+func (l *ReferenceLookup) store(update func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	update()
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// releaseCommitLimit bounds the commits read since the last tag.
+const releaseCommitLimit = 1000
+
+// Bump lists and tag prefix used when the config file predates the release
+// settings.
+var (
+	defaultMajorTypes = []string{"breaking"}
+	defaultMinorTypes = []string{"feat"}
+	defaultPatchTypes = []string{"fix", "perf", "refactor", "revert", "db"}
+)
+
+const defaultTagPrefix = "v"
+
+// Bump is a semantic version increment.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the name of the bump.
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// Version is a semantic version.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// String returns the version as "major.minor.patch".
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Bump returns the next version for the bump.
+func (v Version) Bump(bump Bump) Version {
+	switch bump {
+	case BumpMajor:
+		return Version{Major: v.Major + 1}
+	case BumpMinor:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	case BumpPatch:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	default:
+		return v
+	}
+}
+
+// ParseVersion parses a tag such as "v1.2.3" with the given prefix. Pre-release
+// and build suffixes such as "-rc.1" or "+build" are ignored.
+func ParseVersion(tag string, prefix string) (Version, bool) {
+	core, found := strings.CutPrefix(strings.TrimSpace(tag), prefix)
+	if !found {
+		return Version{}, false
+	}
+
+	if index := strings.IndexAny(core, "-+"); index >= 0 {
+		core = core[:index]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Version{}, false
+		}
+		numbers[i] = number
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, true
+}
+
+// Release is the next release computed from the commits since the last tag.
+type Release struct {
+	PreviousTag string // empty when the repository has no release tag yet
+	Previous    Version
+	Next        Version
+	Bump        Bump
+	Commits     []CommitInfo // newest first
+}
+
+// Tag returns the tag of the next release.
+func (r *Release) Tag(config *settings.Config) string {
+	return ReleaseTagPrefix(config) + r.Next.String()
+}
+
+// ReleaseTagPrefix returns the tag prefix from the release config, or the
+// default prefix when the config file has no release settings. An empty
+// prefix set next to the bump lists is kept.
+func ReleaseTagPrefix(config *settings.Config) string {
+	release := config.Release
+	if release.TagPrefix == "" && release.Major == nil && release.Minor == nil && release.Patch == nil {
+		return defaultTagPrefix
+	}
+	return release.TagPrefix
+}
+
+// CommitBump returns the version bump the commit calls for, from the commit
+// type lists in the release config, or the default lists for those missing
+// from it. Breaking changes always bump the major version.
+func CommitBump(config *settings.Config, commit CommitInfo) Bump {
+	if strings.Contains(commit.Message, "BREAKING CHANGE") {
+		return BumpMajor
+	}
+	if !commit.Conforming {
+		return BumpNone
+	}
+
+	commitType := commit.Parsed.CommitType
+	switch {
+	case strings.HasSuffix(commitType, "!"), slices.Contains(typesOrDefault(config.Release.Major, defaultMajorTypes), commitType):
+		return BumpMajor
+	case slices.Contains(typesOrDefault(config.Release.Minor, defaultMinorTypes), commitType):
+		return BumpMinor
+	case slices.Contains(typesOrDefault(config.Release.Patch, defaultPatchTypes), commitType):
+		return BumpPatch
+	default:
+		return BumpNone
+	}
+}
+
+// typesOrDefault returns the commit types, or the defaults when the list is
+// missing from the config. An empty list is kept.
+func typesOrDefault(types []string, defaults []string) []string {
+	if types == nil {
+		return defaults
+	}
+	return types
+}
+
+// NextRelease reads the commits since the last release tag, parses them with
+// the commit format and computes the next version from the largest bump. The
+// first release is bumped from 0.0.0.
+func NextRelease(helper helpers.GitHelper, config *settings.Config) (*Release, error) {
+	release := &Release{}
+
+	prefix := ReleaseTagPrefix(config)
+	revisionRange := ""
+	if output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitLastTag, prefix)); err == nil {
+		tag := strings.TrimSpace(output)
+		if version, ok := ParseVersion(tag, prefix); ok {
+			release.PreviousTag, release.Previous = tag, version
+			revisionRange = tag + "..HEAD"
+		}
+	}
+
+	commits, err := GetCommits(helper, config, revisionRange, releaseCommitLimit)
+	if err != nil {
+		return nil, err
+	}
+	release.Commits = commits
+
+	for _, commit := range commits {
+		release.Bump = max(release.Bump, CommitBump(config, commit))
+	}
+	release.Next = release.Previous.Bump(release.Bump)
+
+	return release, nil
+}
+
+// ReleaseMessage returns the annotated tag message: a title followed by the
+// commits that bump the version, grouped from major to patch.
+func ReleaseMessage(config *settings.Config, release *Release) string {
+	lines := []string{"Release " + release.Tag(config)}
+
+	sections := []struct {
+		bump  Bump
+		title string
+	}{
+		{BumpMajor, "Breaking changes"},
+		{BumpMinor, "Features"},
+		{BumpPatch, "Fixes and improvements"},
+	}
+
+	for _, section := range sections {
+		var entries []string
+		for _, commit := range release.Commits {
+			if CommitBump(config, commit) == section.bump {
+				entries = append(entries, "- "+releaseEntry(commit))
+			}
+		}
+
+		if len(entries) > 0 {
+			lines = append(lines, "", section.title+":")
+			lines = append(lines, entries...)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CreateReleaseTag creates an annotated tag with the message.
+func CreateReleaseTag(helper helpers.GitHelper, tag string, message string) error {
+	cmd := fmt.Sprintf(commands.GitCreateTag, tag, helpers.EscapeSingleQuotes(message))
+	if output, err := helper.ExecuteCommand(cmd); err != nil {
+		log.Printf("Failed to create tag %s: %v\nOutput: %q", tag, err, output)
+		return fmt.Errorf("failed to create tag %s: %w", tag, err)
+	}
+	return nil
+}
+
+// PushTag pushes the tag to the remote.
+func PushTag(helper helpers.GitHelper, remote string, tag string) error {
+	return runGitAction(helper, fmt.Sprintf(commands.GitPushTag, remote, tag), "push "+tag+" to "+remote)
+}
+
+// releaseEntry describes a commit in the release message, e.g.
+// "feat: Add login (SS-12)".
+func releaseEntry(commit CommitInfo) string {
+	if !commit.Conforming {
+		return commit.Subject()
+	}
+
+	summary, _, _ := strings.Cut(commit.Parsed.Summary, "\n")
+	entry := fmt.Sprintf("%s: %s", commit.Parsed.CommitType, strings.TrimSpace(summary))
+	if commit.Parsed.Jira != "" {
+		entry += " (" + commit.Parsed.Jira + ")"
+	}
+	return entry
+}
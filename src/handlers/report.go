@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const reportCommitLimit = 5000
+
+var (
+	// referenceTrailerPattern matches trailers that name references, e.g.
+	// "Refs: SS-1, SS-2" or "Closes #12".
+	referenceTrailerPattern = regexp.MustCompile(`(?im)^(?:refs|references|ref|closes|fixes|resolves|jira)\s*:?\s+(.+)$`)
+	// referencePattern matches Jira keys and forge issue references, so prose
+	// such as "Fixes the login page" is not taken for references.
+	referencePattern = regexp.MustCompile(`^(?:[A-Z][A-Z0-9_]+-[0-9]+|(?:[\w.-]+/)*[\w.-]*#[0-9]+)$`)
+)
+
+// ReportCommit is a commit listed under a reference in the report.
+type ReportCommit struct {
+	Hash    string    `json:"hash"`
+	Type    string    `json:"type"`
+	Version string    `json:"version"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Files   []string  `json:"files"`
+}
+
+// ReportGroup holds the commits of one reference, with the authors, files and
+// versions of all of them.
+type ReportGroup struct {
+	Reference string         `json:"reference"` // empty for commits without a reference
+	Commits   []ReportCommit `json:"commits"`
+	Authors   []string       `json:"authors"`
+	Files     []string       `json:"files"`
+	Versions  []string       `json:"versions"`
+}
+
+// Report lists the commits between two refs, grouped by reference.
+type Report struct {
+	From   string        `json:"from"` // empty when the report starts at the first commit
+	To     string        `json:"to"`
+	Groups []ReportGroup `json:"groups"`
+}
+
+// BuildReport reads the commits between the refs and groups them by their
+// references, taken from the $jira field and from reference trailers such as
+// "Refs:" or "Closes". A commit with several references is listed under each
+// of them. Commits without a reference are grouped last. An empty from starts
+// at the last release tag and an empty to means HEAD.
+func BuildReport(helper helpers.GitHelper, config *settings.Config, from string, to string) (*Report, error) {
+	from, to, revisionRange := releaseRange(helper, config, from, to)
+
+	commits, err := GetCommits(helper, config, revisionRange, reportCommitLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitLogFiles, reportCommitLimit, revisionRange))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the changed files: %w", err)
+	}
+	files := parseLogFiles(output)
+
+	report := &Report{From: from, To: to}
+	groups := map[string]*ReportGroup{}
+	var order []string
+	for _, commit := range commits {
+		entry := ReportCommit{
+			Hash:    commit.Hash,
+			Type:    commit.Parsed.CommitType,
+			Version: commit.Parsed.Version,
+			Subject: commit.Subject(),
+			Author:  commit.Author,
+			Date:    commit.Date,
+			Files:   files[commit.Hash],
+		}
+
+		references := CommitReferences(commit)
+		if len(references) == 0 {
+			references = []string{""}
+		}
+
+		for _, reference := range references {
+			group, ok := groups[reference]
+			if !ok {
+				group = &ReportGroup{Reference: reference}
+				groups[reference] = group
+				order = append(order, reference)
+			}
+			group.add(entry)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		// Commits without a reference go last.
+		if order[i] == "" || order[j] == "" {
+			return order[j] == ""
+		}
+		return order[i] < order[j]
+	})
+	for _, reference := range order {
+		report.Groups = append(report.Groups, *groups[reference])
+	}
+
+	return report, nil
+}
+
+// CommitReferences returns the references of the commit: the $jira field of a
+// conforming message first, then the Jira keys and issue references named in
+// reference trailers below the subject, without duplicates.
+func CommitReferences(commit CommitInfo) []string {
+	var references []string
+	add := func(reference string) {
+		reference = strings.TrimSpace(reference)
+		if reference != "" && !slices.Contains(references, reference) {
+			references = append(references, reference)
+		}
+	}
+
+	if commit.Conforming {
+		add(commit.Parsed.Jira)
+	}
+	_, body, _ := strings.Cut(commit.Message, "\n")
+	for _, match := range referenceTrailerPattern.FindAllStringSubmatch(body, -1) {
+		for _, reference := range strings.FieldsFunc(match[1], func(r rune) bool { return r == ',' || r == ' ' }) {
+			if reference = strings.TrimRight(reference, "."); referencePattern.MatchString(reference) {
+				add(reference)
+			}
+		}
+	}
+
+	return references
+}
+
+// Markdown renders the report with a section per reference.
+func (r *Report) Markdown() string {
+	var builder strings.Builder
+
+	builder.WriteString("# Commits by reference (" + r.rangeName() + ")\n")
+	if len(r.Groups) == 0 {
+		builder.WriteString("\nNo commits.\n")
+	}
+
+	for _, group := range r.Groups {
+		builder.WriteString("\n## " + referenceName(group.Reference) + "\n\n")
+		builder.WriteString("- Authors: " + joinOrNone(group.Authors) + "\n")
+		builder.WriteString("- Versions: " + joinOrNone(group.Versions) + "\n")
+		builder.WriteString(fmt.Sprintf("- Files touched: %d\n", len(group.Files)))
+		builder.WriteString("\n| Commit | Date | Author | Subject |\n| --- | --- | --- | --- |\n")
+		for _, commit := range group.Commits {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				shortHash(commit.Hash), commit.Date.Format(time.DateOnly), commit.Author, strings.ReplaceAll(commit.Subject, "|", `\|`)))
+		}
+
+		if len(group.Files) > 0 {
+			builder.WriteString("\nFiles:\n\n")
+			for _, file := range group.Files {
+				builder.WriteString("- `" + file + "`\n")
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// CSV renders the report with a row per commit and reference. Files are
+// separated by semicolons.
+func (r *Report) CSV() (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	rows := [][]string{{"reference", "hash", "date", "author", "type", "version", "subject", "files"}}
+	for _, group := range r.Groups {
+		for _, commit := range group.Commits {
+			rows = append(rows, []string{
+				group.Reference, commit.Hash, commit.Date.Format(time.RFC3339), commit.Author,
+				commit.Type, commit.Version, commit.Subject, strings.Join(commit.Files, ";"),
+			})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return "", fmt.Errorf("failed to write csv: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// JSON renders the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// add lists the commit in the group and collects its author, files and
+// version.
+func (g *ReportGroup) add(commit ReportCommit) {
+	g.Commits = append(g.Commits, commit)
+	g.Authors = appendUnique(g.Authors, commit.Author)
+	g.Versions = appendUnique(g.Versions, commit.Version)
+	for _, file := range commit.Files {
+		g.Files = appendUnique(g.Files, file)
+	}
+	sort.Strings(g.Files)
+}
+
+// rangeName describes the range of the report, e.g. "v1.2.0..HEAD".
+func (r *Report) rangeName() string {
+	if r.From == "" {
+		return r.To
+	}
+	return r.From + ".." + r.To
+}
+
+// parseLogFiles parses the output of commands.GitLogFiles into the files
+// touched by each commit hash.
+func parseLogFiles(output string) map[string][]string {
+	files := map[string][]string{}
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		hash := strings.TrimSpace(lines[0])
+		if hash == "" {
+			continue
+		}
+
+		for _, line := range lines[1:] {
+			if file := strings.TrimSpace(line); file != "" {
+				files[hash] = append(files[hash], file)
+			}
+		}
+	}
+	return files
+}
+
+// appendUnique appends the value when it is not empty and not in the list.
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+// referenceName returns the heading of a report group.
+func referenceName(reference string) string {
+	if reference == "" {
+		return "No reference"
+	}
+	return reference
+}
+
+// joinOrNone joins the values with commas, or returns "none".
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
+// shortHash returns the first 7 characters of the hash.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
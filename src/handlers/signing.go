@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// signingFailures maps fragments of git and gpg/ssh error output to a clear
+// explanation of what went wrong while signing.
+var signingFailures = []struct {
+	fragment string
+	reason   string
+}{
+	{"no secret key", "the signing key was not found in your keyring"},
+	{"secret key not available", "the signing key was not found in your keyring"},
+	{"no pinentry", "gpg could not ask for the key passphrase (pinentry missing)"},
+	{"inappropriate ioctl for device", "gpg could not ask for the key passphrase, try 'export GPG_TTY=$(tty)'"},
+	{"couldn't get agent socket", "the ssh agent is not running"},
+	{"could not open a connection to your authentication agent", "the ssh agent is not running"},
+	{"agent refused operation", "the ssh agent refused to sign, is the key added with ssh-add?"},
+	{"no such file or directory", "the signing key file was not found"},
+	{"gpg failed to sign the data", "gpg failed to sign the commit, is gpg-agent running?"},
+	{"failed to write commit object", "git could not sign the commit"},
+}
+
+// ResolveSigning resolves the signing settings for this run. The "auto" mode is
+// resolved to "always" or "never" by reading git's commit.gpgsign setting, and
+// an empty key falls back to git's user.signingkey.
+func ResolveSigning(helper helpers.GitHelper, config *settings.Config) settings.Signing {
+	signing := config.Signing
+
+	switch signing.Sign {
+	case settings.SignAlways, settings.SignNever:
+	default:
+		signing.Sign = settings.SignNever
+		if gitConfigValue(helper, "commit.gpgsign") == "true" {
+			signing.Sign = settings.SignAlways
+		}
+	}
+
+	if signing.Sign == settings.SignAlways && signing.Key == "" {
+		signing.Key = gitConfigValue(helper, "user.signingkey")
+	}
+
+	return signing
+}
+
+// SigningFlags returns the git commit flags for the signing settings, with a
+// leading space, so they can be appended to a commit command.
+func SigningFlags(signing settings.Signing) string {
+	var flags []string
+
+	switch signing.Sign {
+	case settings.SignAlways:
+		if signing.Key != "" {
+			flags = append(flags, fmt.Sprintf("-S'%s'", signing.Key))
+		} else {
+			flags = append(flags, "-S")
+		}
+	case settings.SignNever:
+		flags = append(flags, "--no-gpg-sign")
+	}
+
+	if signing.SignOff {
+		flags = append(flags, "--signoff")
+	}
+
+	if len(flags) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(flags, " ")
+}
+
+// DescribeSigning returns a short description of how the commit will be
+// signed, for showing in the commit confirmation.
+func DescribeSigning(helper helpers.GitHelper, signing settings.Signing) string {
+	var description string
+
+	switch signing.Sign {
+	case settings.SignAlways:
+		format := gitConfigValue(helper, "gpg.format")
+		if format == "" {
+			format = "openpgp"
+		}
+
+		description = "Signed: yes (" + format
+		if signing.Key != "" {
+			description += ", key " + signing.Key
+		}
+		description += ")"
+	case settings.SignNever:
+		description = "Signed: no"
+	default:
+		return ""
+	}
+
+	if signing.SignOff {
+		description += ", Signed-off-by trailer"
+	}
+
+	return description
+}
+
+// DescribeCommitError wraps a failed commit error with a clear explanation when
+// the failure was caused by signing.
+func DescribeCommitError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := strings.ToLower(err.Error())
+	if !strings.Contains(message, "sign") && !strings.Contains(message, "gpg") && !strings.Contains(message, "agent") {
+		return err
+	}
+
+	for _, failure := range signingFailures {
+		if strings.Contains(message, failure.fragment) {
+			return fmt.Errorf("signing failed: %s: %w", failure.reason, err)
+		}
+	}
+
+	return errors.Join(errors.New("signing failed"), err)
+}
+
+// gitConfigValue returns the value of a git config key, or an empty string when
+// it is not set.
+func gitConfigValue(helper helpers.GitHelper, key string) string {
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitConfigGet, key))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
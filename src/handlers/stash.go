@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// unselectedStashPrefix starts the message of the stash holding the changes
+// left out of a commit, so it can be found again to restore it.
+const unselectedStashPrefix = "git-commit-ui: changes not selected for commit"
+
+// Stash is an entry of the stash list.
+type Stash struct {
+	Ref     string // e.g. "stash@{0}"
+	Date    string // relative date, e.g. "5 minutes ago"
+	Message string // e.g. "On main: work in progress"
+}
+
+// Label returns a one line description of the stash.
+func (s Stash) Label() string {
+	return fmt.Sprintf("%s  %s  %s", s.Ref, s.Date, s.Message)
+}
+
+// GetStashes returns the stash list, most recent first.
+func GetStashes(helper helpers.GitHelper) ([]Stash, error) {
+	output, err := helper.ExecuteCommand(commands.GitStashList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+
+	var stashes []Stash
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "stash@{") {
+			continue
+		}
+		stashes = append(stashes, Stash{Ref: fields[0], Date: fields[1], Message: strings.TrimSpace(fields[2])})
+	}
+
+	return stashes, nil
+}
+
+// ShowStash returns the files and diff of the stash.
+func ShowStash(helper helpers.GitHelper, ref string) (string, error) {
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitStashShow, ref))
+	if err != nil {
+		return "", fmt.Errorf("failed to show %s: %w", ref, err)
+	}
+	return output, nil
+}
+
+// ApplyStash applies the stash and keeps it in the stash list.
+func ApplyStash(helper helpers.GitHelper, ref string) error {
+	return runGitAction(helper, fmt.Sprintf(commands.GitStashApply, ref), "apply "+ref)
+}
+
+// PopStash applies the stash and removes it from the stash list. When applying
+// it conflicts, git keeps the stash.
+func PopStash(helper helpers.GitHelper, ref string) error {
+	return runGitAction(helper, fmt.Sprintf(commands.GitStashPop, ref), "pop "+ref)
+}
+
+// DropStash removes the stash without applying it.
+func DropStash(helper helpers.GitHelper, ref string) error {
+	return runGitAction(helper, fmt.Sprintf(commands.GitStashDrop, ref), "drop "+ref)
+}
+
+// UnselectedChanges returns the files with changes that are not staged for the
+// commit, including untracked files. Files that also have staged changes are
+// returned as partial instead, as stashing them would take the staged changes
+// out of the commit too.
+func UnselectedChanges(helper helpers.GitHelper) (unselected []string, partial []string) {
+	output, err := helper.ExecuteCommand(commands.GitChangedFiles)
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		// Porcelain lines look like "XY filename", X is the index status and Y
+		// the work tree status
+		if len(line) < 4 || line[1] == ' ' {
+			continue
+		}
+
+		file := strings.TrimSpace(line[3:])
+		if line[0] != ' ' && line[0] != '?' {
+			partial = append(partial, file)
+			continue
+		}
+		unselected = append(unselected, file)
+	}
+	return unselected, partial
+}
+
+// StashUnselectedChanges stashes the files, which should have no staged
+// changes, so they do not affect the commit's checks. It returns the stash
+// message to pass to RestoreUnselectedChanges.
+func StashUnselectedChanges(helper helpers.GitHelper, files []string) (string, error) {
+	message := fmt.Sprintf("%s %s", unselectedStashPrefix, time.Now().Format(time.RFC3339))
+	if err := runGitAction(helper, fmt.Sprintf(commands.GitStashFiles, message, quoteFiles(files)), "stash the changes not selected for commit"); err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// RestoreUnselectedChanges pops the stash created by StashUnselectedChanges.
+// When it cannot be restored cleanly, the stash is kept and the user is told
+// how to restore it.
+func RestoreUnselectedChanges(helper helpers.GitHelper, message string) error {
+	stashes, err := GetStashes(helper)
+	if err != nil {
+		return err
+	}
+
+	for _, stash := range stashes {
+		if !strings.HasSuffix(stash.Message, message) {
+			continue
+		}
+
+		if err := PopStash(helper, stash.Ref); err != nil {
+			return fmt.Errorf("the changes not selected for commit are kept in %s, restore them with 'git stash pop': %w", stash.Ref, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("the stash %q was not found", message)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// StaticCommitForm is a commit form filled from command line flags instead of
+// prompts, for scripted commits. The body, when given, is added to the summary
+// after a blank line.
+type StaticCommitForm struct {
+	Version, CommitType, Jira, Summary, Body string
+	Types                                    []string
+}
+
+// Run validates the values; nothing is prompted.
+func (f *StaticCommitForm) Run() error {
+	return f.Validate()
+}
+
+// Validate returns an error when the type or summary is missing or the type is
+// not one of the configured commit types.
+func (f *StaticCommitForm) Validate() error {
+	if strings.TrimSpace(f.CommitType) == "" {
+		return fmt.Errorf("missing commit type, use --type")
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, f.CommitType) {
+		return fmt.Errorf("invalid commit type %q, expected one of: %s", f.CommitType, strings.Join(f.Types, ", "))
+	}
+	if strings.TrimSpace(f.Summary) == "" {
+		return fmt.Errorf("missing summary, use --summary")
+	}
+	return nil
+}
+
+// SetDefaultValues only keeps the commit types to validate against. The
+// defaults suggested from the history and the changed files are ignored, so a
+// scripted commit contains exactly what the flags give.
+func (f *StaticCommitForm) SetDefaultValues(commitTypes []string, defaultCommitType string, defaultVersion string, defaultJiraReference string) {
+	f.Types = commitTypes
+}
+
+// GetValues returns the version, commit type, reference and the summary
+// followed by the body.
+func (f *StaticCommitForm) GetValues() (string, string, string, string) {
+	summary := strings.TrimSpace(f.Summary)
+	if body := strings.TrimSpace(f.Body); body != "" {
+		summary += "\n\n" + body
+	}
+	return strings.TrimSpace(f.Version), f.CommitType, strings.TrimSpace(f.Jira), summary
+}
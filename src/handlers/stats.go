@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const statsCommitLimit = 10000
+
+// StatCount is the number of commits for one type, author, reference or week.
+type StatCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Stats summarises the commit history and how well it follows the commit
+// format.
+type Stats struct {
+	Range              string      `json:"range"`
+	Commits            int         `json:"commits"`
+	NonConforming      int         `json:"non_conforming"`
+	NonConformingRatio float64     `json:"non_conforming_ratio"`
+	AverageFiles       float64     `json:"average_files"` // merge commits excluded
+	AverageLines       float64     `json:"average_lines"` // lines added and deleted, merge commits excluded
+	ByType             []StatCount `json:"by_type"`       // conforming commits only
+	ByAuthor           []StatCount `json:"by_author"`
+	ByReference        []StatCount `json:"by_reference"`
+	ByWeek             []StatCount `json:"by_week"` // ISO weeks, e.g. "2025-W01", oldest first
+}
+
+// commitChanges is the number of files and lines a commit changed.
+type commitChanges struct {
+	Files, Lines int
+	Merge        bool // git log lists no changes for merge commits
+}
+
+// BuildStats reads the commits in the revision range, HEAD when empty, through
+// the commit format and counts them by type, author, reference and week.
+// Merge commits are counted like any other commit, except in the averages of
+// files and lines changed, as git lists no changes for them.
+func BuildStats(helper helpers.GitHelper, config *settings.Config, revisionRange string) (*Stats, error) {
+	if revisionRange == "" {
+		revisionRange = "HEAD"
+	}
+
+	commits, err := GetCommits(helper, config, revisionRange, statsCommitLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitLogNumstat, statsCommitLimit, revisionRange))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the changed lines: %w", err)
+	}
+	changes := parseNumstat(output)
+
+	stats := &Stats{Range: revisionRange, Commits: len(commits)}
+	byType, byAuthor, byReference, byWeek := map[string]int{}, map[string]int{}, map[string]int{}, map[string]int{}
+	var files, lines, measured int
+	for _, commit := range commits {
+		if commit.Conforming && commit.Parsed.CommitType != "" {
+			byType[commit.Parsed.CommitType]++
+		}
+		if !commit.Conforming {
+			stats.NonConforming++
+		}
+		byAuthor[commit.Author]++
+		for _, reference := range CommitReferences(commit) {
+			byReference[reference]++
+		}
+		if !commit.Date.IsZero() {
+			year, week := commit.Date.ISOWeek()
+			byWeek[fmt.Sprintf("%d-W%02d", year, week)]++
+		}
+
+		if change, ok := changes[commit.Hash]; ok && !change.Merge {
+			files += change.Files
+			lines += change.Lines
+			measured++
+		}
+	}
+
+	if stats.Commits > 0 {
+		stats.NonConformingRatio = float64(stats.NonConforming) / float64(stats.Commits)
+	}
+	if measured > 0 {
+		stats.AverageFiles = float64(files) / float64(measured)
+		stats.AverageLines = float64(lines) / float64(measured)
+	}
+
+	stats.ByType = sortedCounts(byType)
+	stats.ByAuthor = sortedCounts(byAuthor)
+	stats.ByReference = sortedCounts(byReference)
+	stats.ByWeek = sortedCounts(byWeek)
+	sort.Slice(stats.ByWeek, func(i, j int) bool { return stats.ByWeek[i].Name < stats.ByWeek[j].Name })
+
+	return stats, nil
+}
+
+// Summary returns the totals, the non-conforming ratio and the averages, one
+// per line.
+func (s *Stats) Summary() string {
+	return fmt.Sprintf("Commits: %d (%s)\nNon-conforming messages: %d (%.1f%%)\nAverage files per commit: %.1f\nAverage lines per commit: %.1f\n",
+		s.Commits, s.Range, s.NonConforming, s.NonConformingRatio*100, s.AverageFiles, s.AverageLines)
+}
+
+// JSON renders the stats as indented JSON.
+func (s *Stats) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// BarChart renders the counts as horizontal bars, scaled so the largest count
+// fills the width.
+func BarChart(counts []StatCount, width int) string {
+	if len(counts) == 0 {
+		return "No data.\n"
+	}
+
+	nameWidth, maxCount := 0, 0
+	for _, count := range counts {
+		nameWidth = max(nameWidth, len([]rune(count.Name)))
+		maxCount = max(maxCount, count.Count)
+	}
+
+	var builder strings.Builder
+	for _, count := range counts {
+		bar := 0
+		if maxCount > 0 {
+			bar = max(count.Count*width/maxCount, 1)
+		}
+		builder.WriteString(fmt.Sprintf("%-*s %s %d\n", nameWidth, count.Name, strings.Repeat("█", bar), count.Count))
+	}
+	return builder.String()
+}
+
+// sortedCounts returns the counts with the largest first, then by name.
+func sortedCounts(counts map[string]int) []StatCount {
+	sorted := []StatCount{}
+	for name, count := range counts {
+		sorted = append(sorted, StatCount{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// parseNumstat parses the output of commands.GitLogNumstat into the files and
+// lines changed by each commit hash. Binary files count as files without lines.
+// Each record starts with the hash and the parent hashes, so merge commits can
+// be told apart.
+func parseNumstat(output string) map[string]commitChanges {
+	changes := map[string]commitChanges{}
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		hashes := strings.Fields(lines[0])
+		if len(hashes) == 0 {
+			continue
+		}
+
+		change := commitChanges{Merge: len(hashes) > 2}
+		for _, line := range lines[1:] {
+			fields := strings.SplitN(strings.TrimSpace(line), "\t", 3)
+			if len(fields) < 3 {
+				continue
+			}
+
+			change.Files++
+			added, _ := strconv.Atoi(fields[0])
+			deleted, _ := strconv.Atoi(fields[1])
+			change.Lines += added + deleted
+		}
+		changes[hashes[0]] = change
+	}
+	return changes
+}
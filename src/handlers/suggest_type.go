@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// TypeHintSetter is implemented by commit forms that can explain to the user why
+// a commit type was preselected.
+type TypeHintSetter interface {
+	SetTypeHint(hint string)
+}
+
+// SuggestCommitType picks a commit type for the given files using the type rules
+// from the configuration. The first rule whose globs match every file wins, as
+// long as its type is one of the configured commit types.
+//
+// It returns the suggested type and a short reason for the suggestion. When no
+// rule matches, the default commit type is returned with an empty reason.
+func SuggestCommitType(config *settings.Config, files []string) (commitType string, reason string) {
+	files = normaliseFilePaths(files)
+	if len(files) == 0 {
+		return config.DefaultCommitType, ""
+	}
+
+	for _, rule := range config.TypeRules {
+		if rule.Type == "" || len(rule.Globs) == 0 {
+			continue
+		}
+
+		if len(config.CommitTypes) > 0 && !slices.Contains(config.CommitTypes, rule.Type) {
+			continue
+		}
+
+		if matchesAllFiles(rule.Globs, files) {
+			return rule.Type, fmt.Sprintf("Suggested '%s': all %d file(s) match %s", rule.Type, len(files), strings.Join(rule.Globs, ", "))
+		}
+	}
+
+	return config.DefaultCommitType, ""
+}
+
+// matchesAllFiles reports whether every file matches at least one of the globs.
+func matchesAllFiles(globs []string, files []string) bool {
+	for _, file := range files {
+		matched := false
+		for _, glob := range globs {
+			if helpers.MatchGlob(glob, file) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normaliseFilePaths drops empty entries and resolves renames reported by
+// 'git status --porcelain' ("old -> new") to the new path.
+func normaliseFilePaths(files []string) []string {
+	var normalised []string
+	for _, file := range files {
+		if _, newPath, found := strings.Cut(file, " -> "); found {
+			file = newPath
+		}
+
+		file = strings.Trim(strings.TrimSpace(file), "\"")
+		if file != "" {
+			normalised = append(normalised, file)
+		}
+	}
+
+	return normalised
+}
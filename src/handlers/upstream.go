@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+)
+
+// Upstream is the tracking branch of a local branch, with the number of
+// commits the local branch is ahead of and behind it.
+type Upstream struct {
+	Remote string
+	Branch string // branch name on the remote, which may differ from the local name
+	Ahead  int
+	Behind int
+}
+
+// Name returns the upstream as git shows it, e.g. "origin/main".
+func (u *Upstream) Name() string {
+	return u.Remote + "/" + u.Branch
+}
+
+// GetUpstream returns the tracking branch of the branch from its
+// branch.<name>.remote and branch.<name>.merge settings, or nil when the
+// branch has no upstream yet.
+func GetUpstream(helper helpers.GitHelper, branch string) *Upstream {
+	remote := gitConfigValue(helper, fmt.Sprintf("branch.%s.remote", branch))
+	merge := gitConfigValue(helper, fmt.Sprintf("branch.%s.merge", branch))
+	if remote == "" || remote == "." || merge == "" {
+		return nil
+	}
+
+	return &Upstream{Remote: remote, Branch: strings.TrimPrefix(merge, "refs/heads/")}
+}
+
+// FetchRemote fetches the remote so the ahead/behind counts are up to date.
+func FetchRemote(helper helpers.GitHelper, remote string) error {
+	if output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitFetch, remote)); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w: %s", remote, err, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// UpdateAheadBehind counts the commits HEAD is ahead of and behind the upstream.
+func UpdateAheadBehind(helper helpers.GitHelper, upstream *Upstream) error {
+	output, err := helper.ExecuteCommand(fmt.Sprintf(commands.GitAheadBehind, "HEAD", upstream.Name()))
+	if err != nil {
+		return fmt.Errorf("failed to compare with %s: %w", upstream.Name(), err)
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return fmt.Errorf("unexpected output comparing with %s: %q", upstream.Name(), output)
+	}
+
+	ahead, aheadErr := strconv.Atoi(fields[0])
+	behind, behindErr := strconv.Atoi(fields[1])
+	if aheadErr != nil || behindErr != nil {
+		return fmt.Errorf("unexpected output comparing with %s: %q", upstream.Name(), output)
+	}
+
+	upstream.Ahead, upstream.Behind = ahead, behind
+	return nil
+}
+
+// DescribeUpstream returns a one line status of the branch and its upstream,
+// e.g. "Branch 'main' tracks 'origin/main': 2 ahead, 1 behind".
+func DescribeUpstream(branch string, upstream *Upstream) string {
+	if upstream == nil {
+		return fmt.Sprintf("Branch '%s' has no upstream yet, it will be set by the push", branch)
+	}
+
+	status := "up to date"
+	switch {
+	case upstream.Ahead > 0 && upstream.Behind > 0:
+		status = fmt.Sprintf("%d ahead, %d behind", upstream.Ahead, upstream.Behind)
+	case upstream.Ahead > 0:
+		status = fmt.Sprintf("%d ahead", upstream.Ahead)
+	case upstream.Behind > 0:
+		status = fmt.Sprintf("%d behind", upstream.Behind)
+	}
+
+	return fmt.Sprintf("Branch '%s' tracks '%s': %s", branch, upstream.Name(), status)
+}
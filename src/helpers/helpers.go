@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"runtime"
 	"strings"
 	"unicode"
@@ -54,6 +55,15 @@ func GetExecCommand() func(string, ...string) *exec.Cmd {
 	return execCommand
 }
 
+// verbose prints every command before it is executed.
+var verbose = false
+
+// SetVerbose sets whether ExecuteCommand prints each command to stderr before
+// executing it.
+func SetVerbose(enabled bool) {
+	verbose = enabled
+}
+
 // ExecuteCommand executes a command and returns the output
 func ExecuteCommand(command string) (string, error) {
 	args, err := ParseCommand(strings.TrimSpace(command))
@@ -61,6 +71,10 @@ func ExecuteCommand(command string) (string, error) {
 		return "", fmt.Errorf("failed to parse command string: %w", err)
 	}
 
+	if verbose {
+		fmt.Fprintf(os.Stderr, "+ %s\n", strings.TrimSpace(command))
+	}
+
 	cmd := exec.Command(args[0], args[1:]...)
 
 	output, err := cmd.CombinedOutput()
@@ -72,6 +86,14 @@ func ExecuteCommand(command string) (string, error) {
 	return string(output), nil
 }
 
+// EscapeSingleQuotes escapes the apostrophes in a value placed between single
+// quotes in a command run through ParseCommand: each one closes the quote,
+// adds the apostrophe in double quotes and reopens it, so names like O'Brien
+// survive parsing.
+func EscapeSingleQuotes(value string) string {
+	return strings.ReplaceAll(value, "'", `'"'"'`)
+}
+
 // parseCommand takes a command string and parses it into a slice of strings, respecting quotes to allow for arguments with spaces.
 // It returns an error if the command string is empty, or if there's an unclosed quote.
 //
@@ -122,3 +144,53 @@ func ParseCommand(cmd string) ([]string, error) {
 
 	return args, nil
 }
+
+// MatchGlob reports whether the slash separated file path matches the given glob
+// pattern.
+//
+// The matching follows these rules:
+// - A pattern without a slash is matched against the base name of the file, so "*.md" matches "docs/README.md".
+// - A pattern with a slash is matched segment by segment against the whole path using path.Match.
+// - A "**" segment matches zero or more path segments, so "docs/**" matches everything below docs/.
+func MatchGlob(pattern string, file string) bool {
+	pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+	file = strings.Trim(strings.TrimSpace(file), "/")
+	if pattern == "" || file == "" {
+		return false
+	}
+
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		matched, err := path.Match(pattern, path.Base(file))
+		return err == nil && matched
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+// matchSegments matches the pattern segments against the path segments, expanding
+// "**" to any number of path segments.
+func matchSegments(patterns []string, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+
+	if patterns[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(patterns[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(patterns[0], segments[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchSegments(patterns[1:], segments[1:])
+}
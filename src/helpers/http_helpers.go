@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// HTTPClient is shared by the issue trackers and forges, so requests to the
+// same host reuse their connections.
+var HTTPClient = &http.Client{}
+
+// ErrUnreachable is returned by SendJSON when the service cannot be reached.
+var ErrUnreachable = errors.New("failed to reach")
+
+// StatusError is returned by SendJSON when the service answers with a status
+// of 300 or above.
+type StatusError struct {
+	Service    string
+	StatusCode int
+	Status     string // e.g. "404 Not Found"
+	Message    string // start of the response body
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned %s: %s", e.Service, e.Status, e.Message)
+}
+
+// NewJSONRequest creates a request accepting JSON, with the body encoded as
+// JSON when it is not nil.
+func NewJSONRequest(ctx context.Context, method string, endpoint string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
+
+// SendJSON sends the request and decodes the JSON response into target, if
+// given. The service name is used in error messages. Failures to reach the
+// service wrap ErrUnreachable and error statuses are returned as *StatusError.
+func SendJSON(client *http.Client, request *http.Request, service string, target any) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrUnreachable, service, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return &StatusError{
+			Service:    service,
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Message:    strings.TrimSpace(string(message)),
+		}
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", service, err)
+	}
+
+	return nil
+}
+
+// EnvOrDefault returns the environment variable if set, otherwise the fallback.
+func EnvOrDefault(name string, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -1,6 +1,10 @@
 package helpers
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
 	"github.com/kurianvarkey/gitcommitui/src/settings"
@@ -8,6 +12,28 @@ import (
 
 var confirmPromptFunc = defaultConfirmPrompt
 
+var selectPromptFunc = defaultSelectPrompt
+
+var multiSelectPromptFunc = defaultMultiSelectPrompt
+
+var inputPromptFunc = defaultInputPrompt
+
+var clipboardWriteFunc = clipboard.WriteAll
+
+// requireYes is set by DisablePrompts without yes, so confirmations checked
+// with RequireConfirmation fail instead of taking their default answer.
+var requireYes bool
+
+// ErrConfirmationRequired is returned by RequireConfirmation for a scripted run
+// without yes.
+var ErrConfirmationRequired = errors.New("confirmation required, run with -yes to accept")
+
+// SelectOption is a labelled value offered by ShowSelect.
+type SelectOption struct {
+	Label string
+	Value string
+}
+
 // ShowSpinner shows a spinner with a given title and executes the given action.
 // Spinner type defaults to spinner.Dots if not provided.
 func ShowSpinner(title string, action func(), spinnerType ...spinner.Type) {
@@ -66,3 +92,178 @@ func SetConfirmPromptFunc(f func(string, ...bool) bool) {
 func GetConfirmPromptFunc() func(string, ...bool) bool {
 	return confirmPromptFunc
 }
+
+// ShowSelect displays a searchable list of options with the specified title
+// and returns the value of the selected option. It returns false if the user
+// cancels the prompt or there are no options.
+func ShowSelect(title string, options []SelectOption) (string, bool) {
+	if len(options) == 0 {
+		return "", false
+	}
+	return selectPromptFunc(title, options)
+}
+
+// defaultSelectPrompt displays a select prompt with the specified title and
+// options. The options can be searched by pressing '/'.
+func defaultSelectPrompt(title string, options []SelectOption) (string, bool) {
+	huhOptions := make([]huh.Option[string], len(options))
+	for i, option := range options {
+		huhOptions[i] = huh.NewOption(option.Label, option.Value)
+	}
+
+	var selected string
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(title).
+				Description("Press / to search").
+				Options(huhOptions...).
+				Value(&selected),
+		),
+	).WithTheme(settings.HuhTheme).Run()
+	if err != nil {
+		return "", false
+	}
+
+	return selected, true
+}
+
+// SetSelectPromptFunc sets the function to be used by ShowSelect to prompt the
+// user for a selection. The default is defaultSelectPrompt.
+func SetSelectPromptFunc(f func(string, []SelectOption) (string, bool)) {
+	selectPromptFunc = f
+}
+
+// GetSelectPromptFunc returns the current selection prompt function used by ShowSelect.
+func GetSelectPromptFunc() func(string, []SelectOption) (string, bool) {
+	return selectPromptFunc
+}
+
+// ShowMultiSelect displays a list of options with the specified title, with the
+// given values preselected, and returns the values of the selected options. It
+// returns false if the user cancels the prompt or there are no options.
+func ShowMultiSelect(title string, options []SelectOption, selected []string) ([]string, bool) {
+	if len(options) == 0 {
+		return nil, false
+	}
+	return multiSelectPromptFunc(title, options, selected)
+}
+
+// defaultMultiSelectPrompt displays a multi-select prompt with the specified
+// title and options, with the given values preselected.
+func defaultMultiSelectPrompt(title string, options []SelectOption, selected []string) ([]string, bool) {
+	huhOptions := make([]huh.Option[string], len(options))
+	for i, option := range options {
+		huhOptions[i] = huh.NewOption(option.Label, option.Value)
+	}
+
+	values := append([]string(nil), selected...)
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(title).
+				Description("Press space or x to toggle").
+				Options(huhOptions...).
+				Value(&values),
+		),
+	).WithTheme(settings.HuhTheme).Run()
+	if err != nil {
+		return nil, false
+	}
+
+	return values, true
+}
+
+// SetMultiSelectPromptFunc sets the function to be used by ShowMultiSelect to
+// prompt the user for a selection. The default is defaultMultiSelectPrompt.
+func SetMultiSelectPromptFunc(f func(string, []SelectOption, []string) ([]string, bool)) {
+	multiSelectPromptFunc = f
+}
+
+// GetMultiSelectPromptFunc returns the current prompt function used by ShowMultiSelect.
+func GetMultiSelectPromptFunc() func(string, []SelectOption, []string) ([]string, bool) {
+	return multiSelectPromptFunc
+}
+
+// ShowInput displays a text input with the specified title, pre-filled with
+// the given value, and returns the entered text. It returns false if the user
+// cancels the prompt.
+func ShowInput(title string, value string) (string, bool) {
+	return inputPromptFunc(title, value)
+}
+
+// defaultInputPrompt displays a text input with the specified title and value.
+func defaultInputPrompt(title string, value string) (string, bool) {
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(title).
+				Value(&value),
+		),
+	).WithTheme(settings.HuhTheme).Run()
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// SetInputPromptFunc sets the function to be used by ShowInput to prompt the
+// user for text. The default is defaultInputPrompt.
+func SetInputPromptFunc(f func(string, string) (string, bool)) {
+	inputPromptFunc = f
+}
+
+// GetInputPromptFunc returns the current prompt function used by ShowInput.
+func GetInputPromptFunc() func(string, string) (string, bool) {
+	return inputPromptFunc
+}
+
+// CopyToClipboard copies the text to the system clipboard.
+func CopyToClipboard(text string) error {
+	return clipboardWriteFunc(text)
+}
+
+// SetClipboardWriteFunc sets the function used by CopyToClipboard. The default
+// writes to the system clipboard.
+func SetClipboardWriteFunc(f func(string) error) {
+	clipboardWriteFunc = f
+}
+
+// GetClipboardWriteFunc returns the current function used by CopyToClipboard.
+func GetClipboardWriteFunc() func(string) error {
+	return clipboardWriteFunc
+}
+
+// DisablePrompts replaces every prompt so nothing waits for input, for
+// scripted runs. Confirmations are accepted when yes is set and otherwise take
+// their default answer; a confirmation without a default, such as the commit
+// message itself, is accepted as the flags already describe it. Without yes,
+// confirmations checked with RequireConfirmation fail instead. Select and
+// input prompts are canceled.
+func DisablePrompts(yes bool) {
+	requireYes = !yes
+	confirmPromptFunc = func(title string, defaultValue ...bool) bool {
+		return yes || len(defaultValue) == 0 || defaultValue[0]
+	}
+	selectPromptFunc = func(string, []SelectOption) (string, bool) {
+		return "", false
+	}
+	multiSelectPromptFunc = func(string, []SelectOption, []string) ([]string, bool) {
+		return nil, false
+	}
+	inputPromptFunc = func(string, string) (string, bool) {
+		return "", false
+	}
+}
+
+// RequireConfirmation returns ErrConfirmationRequired for the confirmation
+// with the given title when prompts are disabled without yes. It is checked
+// before confirmations that would do more than a scripted run asked for, such
+// as initialising a repository, so they fail rather than take their default.
+func RequireConfirmation(title string) error {
+	if !requireYes {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrConfirmationRequired, title)
+}
@@ -12,14 +12,140 @@ var embeddedDefaultConfigData []byte // Embed the default config file content as
 
 // Config represents the structure of our configuration file.
 type Config struct {
-	CommitTypes          []string `json:"commit_types"` // Alias for git_commit_types
-	CommitFormat         string   `json:"commit_format"`
-	DefaultVersion       string   `json:"default_version"`
-	DefaultCommitType    string   `json:"default_commit_type"`
-	DefaultJiraReference string   `json:"default_jira_reference"`
+	CommitTypes          []string    `json:"commit_types"` // Alias for git_commit_types
+	CommitFormat         string      `json:"commit_format"`
+	DefaultVersion       string      `json:"default_version"`
+	DefaultCommitType    string      `json:"default_commit_type"`
+	DefaultJiraReference string      `json:"default_jira_reference"`
+	TypeRules            []TypeRule  `json:"type_rules"`
+	HistorySize          int         `json:"history_size"` // 0 uses the default size, negative disables history
+	Signing              Signing     `json:"signing"`
+	TeamRoster           []string    `json:"team_roster"`   // co-authors offered in addition to the repository history, as "Name <email>"
+	IssueTracker         string      `json:"issue_tracker"` // one of TrackerJira, TrackerGitHub or TrackerGitLab, empty means TrackerJira
+	CloseIssues          bool        `json:"close_issues"`  // add a "Closes <reference>" trailer for trackers that support it
+	Jira                 Jira        `json:"jira"`
+	GitHub               Forge       `json:"github"`
+	GitLab               Forge       `json:"gitlab"`
+	ProtectedBranches    []string    `json:"protected_branches"` // branch globs, e.g. "main" or "release/*", that cannot be committed to directly
+	BranchTemplate       string      `json:"branch_template"`    // name of the feature branch offered instead, e.g. "$type/$jira-$summary-slug"
+	Push                 Push        `json:"push"`
+	PullRequest          PullRequest `json:"pull_request"`
+	Release              Release     `json:"release"`
+	Changelog            Changelog   `json:"changelog"`
 }
 
-const configFileName = "git-commit-ui-config.json"
+// Changelog configures the changelog generated from the commit history.
+type Changelog struct {
+	File     string             `json:"file"`     // Markdown file the changelog is written to, empty means CHANGELOG.md
+	Sections []ChangelogSection `json:"sections"` // commit types to include, in order, commits of other types are left out
+}
+
+// ChangelogSection maps a commit type to the title of its changelog section.
+type ChangelogSection struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+}
+
+// Release configures the release flow that tags the next semantic version.
+// Commit types in none of the lists do not bump the version. Commits whose
+// type ends with "!" or whose message contains "BREAKING CHANGE" always bump
+// the major version.
+type Release struct {
+	TagPrefix string   `json:"tag_prefix"` // e.g. "v" for tags like v1.2.3
+	Major     []string `json:"major"`      // commit types that bump the major version
+	Minor     []string `json:"minor"`      // commit types that bump the minor version
+	Patch     []string `json:"patch"`      // commit types that bump the patch version
+	Push      bool     `json:"push"`       // default answer when asked to push the tag
+}
+
+// Pull strategy values for Push.PullStrategy.
+const (
+	PullRebase = "rebase" // replay the local commits on top of the remote branch
+	PullMerge  = "merge"  // merge the remote branch into the local branch
+)
+
+// Push configures how branches are pushed.
+type Push struct {
+	Fetch        bool   `json:"fetch"`         // fetch the upstream before showing the ahead/behind status
+	PullStrategy string `json:"pull_strategy"` // PullRebase or PullMerge, offered first when a push is rejected, empty means PullRebase
+}
+
+// PullRequest configures the optional pull/merge request step after a push.
+// It uses the GitHub or GitLab settings for the API URL, project and token.
+type PullRequest struct {
+	Enabled   bool     `json:"enabled"`
+	Forge     string   `json:"forge"` // TrackerGitHub or TrackerGitLab, empty detects it from the pushed remote
+	Base      string   `json:"base"`  // target branch, empty uses the repository's default branch
+	Reviewers []string `json:"reviewers"`
+	Labels    []string `json:"labels"`
+	Draft     bool     `json:"draft"`
+}
+
+// Issue tracker values for Config.IssueTracker.
+const (
+	TrackerJira   = "jira"
+	TrackerGitHub = "github"
+	TrackerGitLab = "gitlab"
+)
+
+// Forge configures access to a GitHub or GitLab instance. The GITHUB_TOKEN and
+// GITLAB_TOKEN environment variables take precedence over the token.
+type Forge struct {
+	BaseURL string `json:"base_url"` // API base URL, empty uses https://api.github.com or https://gitlab.com
+	Project string `json:"project"`  // "owner/repo" or "group/project", empty detects it from the origin remote
+	Token   string `json:"token"`
+}
+
+// Jira configures the optional Jira issue lookup. The JIRA_BASE_URL, JIRA_EMAIL
+// and JIRA_API_TOKEN environment variables take precedence over these values,
+// so tokens do not have to be stored in the config file.
+type Jira struct {
+	BaseURL string `json:"base_url"` // e.g. https://company.atlassian.net, empty disables the lookup
+	Email   string `json:"email"`    // Jira Cloud account email for basic auth, empty uses a bearer token
+	Token   string `json:"token"`    // API token or personal access token
+}
+
+// Signing mode values for Signing.Sign.
+const (
+	SignAuto   = "auto"   // honour git's commit.gpgsign setting
+	SignAlways = "always" // always sign with -S
+	SignNever  = "never"  // never sign, even if commit.gpgsign is set
+)
+
+// Signing configures commit signing and the DCO Signed-off-by trailer.
+type Signing struct {
+	Sign    string `json:"sign"`     // one of SignAuto, SignAlways or SignNever, empty means SignAuto
+	Key     string `json:"key"`      // key passed to -S, empty uses git's user.signingkey
+	SignOff bool   `json:"sign_off"` // add a Signed-off-by trailer with --signoff
+}
+
+// TypeRule maps a set of path globs to a commit type. A rule matches when every
+// file in the commit matches at least one of its globs. Rules are evaluated in
+// order and the first match wins.
+type TypeRule struct {
+	Type  string   `json:"type"`
+	Globs []string `json:"globs"`
+}
+
+// DefaultConfigFile is the config file read from the working directory when
+// no other path is set.
+const DefaultConfigFile = "git-commit-ui-config.json"
+
+var configFileName = DefaultConfigFile
+
+// SetConfigFile sets the path of the config file read by LoadConfig. An empty
+// path restores DefaultConfigFile.
+func SetConfigFile(path string) {
+	if path == "" {
+		path = DefaultConfigFile
+	}
+	configFileName = path
+}
+
+// ConfigFile returns the path of the config file read by LoadConfig.
+func ConfigFile() string {
+	return configFileName
+}
 
 // LoadConfig attempts to load a Config object from disk. If the file does not exist, it will be created
 // with default values. If the file exists, it will be read from disk and deserialized into a Config
@@ -63,3 +189,17 @@ func createDefaultConfig() (*Config, error) {
 
 	return &config, nil
 }
+
+// InitConfig writes the default config to the config file. An existing file
+// is only replaced when force is set.
+func InitConfig(force bool) error {
+	if _, err := os.Stat(configFileName); err == nil && !force {
+		return fmt.Errorf("%s already exists", configFileName)
+	}
+
+	if err := os.WriteFile(configFileName, embeddedDefaultConfigData, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", configFileName, err)
+	}
+
+	return nil
+}
@@ -1,8 +1,19 @@
 package settings
 
-import "github.com/charmbracelet/huh"
+import (
+	"os"
+
+	"github.com/charmbracelet/huh"
+)
 
 var (
 	// AppName is the name of the application
 	HuhTheme *huh.Theme = huh.ThemeBase16()
 )
+
+// DisableColor switches the forms to the plain theme and sets NO_COLOR so
+// nothing is rendered in color.
+func DisableColor() {
+	os.Setenv("NO_COLOR", "1")
+	HuhTheme = huh.ThemeBase()
+}
@@ -0,0 +1,99 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ValidateConfigFile reads the config file strictly, rejecting unknown keys
+// such as misspelt options, and returns every problem found in its values. It
+// returns an error when the file cannot be read or parsed.
+func ValidateConfigFile() ([]string, error) {
+	data, err := os.ReadFile(configFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configFileName, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configFileName, err)
+	}
+
+	return ValidateConfig(&config), nil
+}
+
+// ValidateConfig returns the problems found in the config values, e.g. a
+// commit format without $summary or a default type that is not a commit type.
+func ValidateConfig(config *Config) []string {
+	var problems []string
+	addf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if !strings.Contains(config.CommitFormat, "$summary") {
+		addf("commit_format must contain $summary")
+	}
+	if len(config.CommitTypes) == 0 {
+		addf("commit_types must not be empty")
+	}
+
+	hasType := func(commitType string) bool {
+		return len(config.CommitTypes) == 0 || slices.Contains(config.CommitTypes, commitType)
+	}
+
+	if config.DefaultCommitType != "" && !hasType(config.DefaultCommitType) {
+		addf("default_commit_type %q is not one of commit_types", config.DefaultCommitType)
+	}
+	for i, rule := range config.TypeRules {
+		if !hasType(rule.Type) {
+			addf("type_rules[%d]: type %q is not one of commit_types", i, rule.Type)
+		}
+		for _, glob := range rule.Globs {
+			if _, err := path.Match(glob, ""); err != nil {
+				addf("type_rules[%d]: invalid glob %q", i, glob)
+			}
+		}
+	}
+
+	switch config.IssueTracker {
+	case "", TrackerJira, TrackerGitHub, TrackerGitLab:
+	default:
+		addf("issue_tracker %q must be jira, github or gitlab", config.IssueTracker)
+	}
+	switch config.PullRequest.Forge {
+	case "", TrackerGitHub, TrackerGitLab:
+	default:
+		addf("pull_request.forge %q must be github or gitlab", config.PullRequest.Forge)
+	}
+	switch config.Signing.Sign {
+	case "", SignAuto, SignAlways, SignNever:
+	default:
+		addf("signing.sign %q must be auto, always or never", config.Signing.Sign)
+	}
+	switch config.Push.PullStrategy {
+	case "", PullRebase, PullMerge:
+	default:
+		addf("push.pull_strategy %q must be rebase or merge", config.Push.PullStrategy)
+	}
+
+	for _, pattern := range config.ProtectedBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			addf("protected_branches: invalid glob %q", pattern)
+		}
+	}
+	for i, section := range config.Changelog.Sections {
+		if section.Type == "" || section.Title == "" {
+			addf("changelog.sections[%d] needs a type and a title", i)
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,153 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const (
+	defaultGitHubAPI = "https://api.github.com"
+	defaultGitHubWeb = "https://github.com"
+)
+
+// GitHubClient talks to the GitHub Issues REST API.
+type GitHubClient struct {
+	BaseURL    string
+	Project    string // "owner/repo"
+	Token      string
+	HTTPClient *http.Client
+}
+
+// githubIssue is the subset of the GitHub issue resource used by the client.
+type githubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// NewGitHubClient returns a GitHub client for the config, with the GITHUB_TOKEN
+// environment variable taking precedence over the token. The project is
+// detected from the remote URL when the config does not name one. It returns
+// nil when the project cannot be determined.
+func NewGitHubClient(config settings.Forge, remoteURL string) *GitHubClient {
+	client := &GitHubClient{
+		BaseURL:    strings.TrimRight(config.BaseURL, "/"),
+		Project:    strings.Trim(config.Project, "/"),
+		Token:      helpers.EnvOrDefault("GITHUB_TOKEN", config.Token),
+		HTTPClient: helpers.HTTPClient,
+	}
+
+	if client.BaseURL == "" {
+		client.BaseURL = defaultGitHubAPI
+	}
+	if client.Project == "" {
+		client.Project = ProjectFromRemoteURL(remoteURL)
+	}
+	if client.Project == "" {
+		return nil
+	}
+
+	return client
+}
+
+// Name returns the name of the tracker.
+func (c *GitHubClient) Name() string {
+	return "GitHub"
+}
+
+// ReferenceHint describes the GitHub reference syntax.
+func (c *GitHubClient) ReferenceHint() string {
+	return "GitHub issue, e.g. #123 or owner/repo#123"
+}
+
+// ValidReference reports whether the reference has the "#123" or
+// "owner/repo#123" syntax.
+func (c *GitHubClient) ValidReference(reference string) bool {
+	project, _, ok := splitForgeReference(reference)
+	return ok && (project == "" || strings.Count(project, "/") == 1)
+}
+
+// IssueURL returns the web page of a "#123" or "owner/repo#123" reference. The
+// web host is derived from the API base URL: api.github.com for github.com and
+// "<host>/api/v3" for GitHub Enterprise.
+func (c *GitHubClient) IssueURL(reference string) string {
+	project, number, ok := splitForgeReference(reference)
+	if !ok || !c.ValidReference(reference) {
+		return ""
+	}
+	if project == "" {
+		project = c.Project
+	}
+
+	web := strings.TrimSuffix(c.BaseURL, "/api/v3")
+	if web == defaultGitHubAPI {
+		web = defaultGitHubWeb
+	}
+	return fmt.Sprintf("%s/%s/issues/%s", web, project, number)
+}
+
+// GetIssue returns the GitHub issue for a "#123" or "owner/repo#123" reference.
+func (c *GitHubClient) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	project, number, ok := splitForgeReference(key)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid GitHub reference: %w", key, ErrIssueNotFound)
+	}
+	if project == "" {
+		project = c.Project
+	}
+
+	var issue githubIssue
+	if err := c.get(ctx, fmt.Sprintf("%s/repos/%s/issues/%s", c.BaseURL, project, number), &issue); err != nil {
+		return nil, err
+	}
+
+	return &Issue{Key: strings.TrimSpace(key), Title: issue.Title, Status: issue.State}, nil
+}
+
+// SearchIssues returns the open issues in the project assigned to the current
+// user that match the text.
+func (c *GitHubClient) SearchIssues(ctx context.Context, text string) ([]Issue, error) {
+	terms := []string{"repo:" + c.Project, "is:issue", "is:open", "assignee:@me"}
+	if text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "#")); text != "" {
+		terms = append(terms, text)
+	}
+
+	query := url.Values{}
+	query.Set("q", strings.Join(terms, " "))
+	query.Set("per_page", "20")
+
+	var result struct {
+		Items []githubIssue `json:"items"`
+	}
+	if err := c.get(ctx, c.BaseURL+"/search/issues?"+query.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, len(result.Items))
+	for i, item := range result.Items {
+		issues[i] = Issue{Key: fmt.Sprintf("#%d", item.Number), Title: item.Title, Status: item.State}
+	}
+
+	return issues, nil
+}
+
+// get performs an authenticated GET request and decodes the JSON response.
+func (c *GitHubClient) get(ctx context.Context, endpoint string, target any) error {
+	request, err := helpers.NewJSONRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("GitHub: %w", err)
+	}
+
+	request.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	return getJSON(c.HTTPClient, request, "GitHub", c.Token != "", target)
+}
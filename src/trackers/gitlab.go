@@ -0,0 +1,144 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+const defaultGitLabURL = "https://gitlab.com"
+
+// GitLabClient talks to the GitLab Issues REST API.
+type GitLabClient struct {
+	BaseURL    string
+	Project    string // "group/project", subgroups allowed
+	Token      string
+	HTTPClient *http.Client
+}
+
+// gitlabIssue is the subset of the GitLab issue resource used by the client.
+type gitlabIssue struct {
+	IID   int    `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// NewGitLabClient returns a GitLab client for the config, with the GITLAB_TOKEN
+// environment variable taking precedence over the token. The project is
+// detected from the remote URL when the config does not name one. It returns
+// nil when the project cannot be determined.
+func NewGitLabClient(config settings.Forge, remoteURL string) *GitLabClient {
+	client := &GitLabClient{
+		BaseURL:    strings.TrimRight(config.BaseURL, "/"),
+		Project:    strings.Trim(config.Project, "/"),
+		Token:      helpers.EnvOrDefault("GITLAB_TOKEN", config.Token),
+		HTTPClient: helpers.HTTPClient,
+	}
+
+	if client.BaseURL == "" {
+		client.BaseURL = defaultGitLabURL
+	}
+	if client.Project == "" {
+		client.Project = ProjectFromRemoteURL(remoteURL)
+	}
+	if client.Project == "" {
+		return nil
+	}
+
+	return client
+}
+
+// Name returns the name of the tracker.
+func (c *GitLabClient) Name() string {
+	return "GitLab"
+}
+
+// ReferenceHint describes the GitLab reference syntax.
+func (c *GitLabClient) ReferenceHint() string {
+	return "GitLab issue, e.g. #12 or group/project#12"
+}
+
+// ValidReference reports whether the reference has the "#12" or
+// "group/project#12" syntax.
+func (c *GitLabClient) ValidReference(reference string) bool {
+	_, _, ok := splitForgeReference(reference)
+	return ok
+}
+
+// IssueURL returns the web page of a "#12" or "group/project#12" reference.
+func (c *GitLabClient) IssueURL(reference string) string {
+	project, number, ok := splitForgeReference(reference)
+	if !ok {
+		return ""
+	}
+	if project == "" {
+		project = c.Project
+	}
+	return fmt.Sprintf("%s/%s/-/issues/%s", c.BaseURL, project, number)
+}
+
+// GetIssue returns the GitLab issue for a "#12" or "group/project#12" reference.
+func (c *GitLabClient) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	project, number, ok := splitForgeReference(key)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid GitLab reference: %w", key, ErrIssueNotFound)
+	}
+	if project == "" {
+		project = c.Project
+	}
+
+	var issue gitlabIssue
+	if err := c.get(ctx, fmt.Sprintf("%s/issues/%s", c.projectURL(project), number), &issue); err != nil {
+		return nil, err
+	}
+
+	return &Issue{Key: strings.TrimSpace(key), Title: issue.Title, Status: issue.State}, nil
+}
+
+// SearchIssues returns the open issues in the project assigned to the current
+// user that match the text.
+func (c *GitLabClient) SearchIssues(ctx context.Context, text string) ([]Issue, error) {
+	query := url.Values{}
+	query.Set("scope", "assigned_to_me")
+	query.Set("state", "opened")
+	query.Set("per_page", "20")
+	if text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "#")); text != "" {
+		query.Set("search", text)
+	}
+
+	var result []gitlabIssue
+	if err := c.get(ctx, c.projectURL(c.Project)+"/issues?"+query.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, len(result))
+	for i, item := range result {
+		issues[i] = Issue{Key: fmt.Sprintf("#%d", item.IID), Title: item.Title, Status: item.State}
+	}
+
+	return issues, nil
+}
+
+// projectURL returns the API URL of the project.
+func (c *GitLabClient) projectURL(project string) string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", c.BaseURL, url.PathEscape(project))
+}
+
+// get performs an authenticated GET request and decodes the JSON response.
+func (c *GitLabClient) get(ctx context.Context, endpoint string, target any) error {
+	request, err := helpers.NewJSONRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("GitLab: %w", err)
+	}
+
+	if c.Token != "" {
+		request.Header.Set("PRIVATE-TOKEN", c.Token)
+	}
+
+	return getJSON(c.HTTPClient, request, "GitLab", c.Token != "", target)
+}
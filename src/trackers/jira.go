@@ -0,0 +1,150 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// jiraKeyPattern matches Jira issue keys such as "SS-1234".
+var jiraKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// JiraClient talks to the Jira REST API.
+type JiraClient struct {
+	BaseURL    string
+	Email      string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// jiraIssue is the subset of the Jira issue resource used by the client.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+// NewJiraClient returns a Jira client for the config, with the JIRA_BASE_URL,
+// JIRA_EMAIL and JIRA_API_TOKEN environment variables taking precedence. It
+// returns nil when no base URL is configured.
+func NewJiraClient(config settings.Jira) *JiraClient {
+	client := &JiraClient{
+		BaseURL:    helpers.EnvOrDefault("JIRA_BASE_URL", config.BaseURL),
+		Email:      helpers.EnvOrDefault("JIRA_EMAIL", config.Email),
+		Token:      helpers.EnvOrDefault("JIRA_API_TOKEN", config.Token),
+		HTTPClient: helpers.HTTPClient,
+	}
+
+	client.BaseURL = strings.TrimRight(client.BaseURL, "/")
+	if client.BaseURL == "" {
+		return nil
+	}
+
+	return client
+}
+
+// Name returns the name of the tracker.
+func (c *JiraClient) Name() string {
+	return "Jira"
+}
+
+// ReferenceHint describes the Jira key syntax.
+func (c *JiraClient) ReferenceHint() string {
+	return "Jira key, e.g. SS-1234"
+}
+
+// ValidReference reports whether the reference is a Jira key.
+func (c *JiraClient) ValidReference(reference string) bool {
+	return jiraKeyPattern.MatchString(strings.ToUpper(strings.TrimSpace(reference)))
+}
+
+// IssueURL returns the web page of the Jira issue with the given key.
+func (c *JiraClient) IssueURL(reference string) string {
+	key := strings.ToUpper(strings.TrimSpace(reference))
+	if !jiraKeyPattern.MatchString(key) {
+		return ""
+	}
+	return fmt.Sprintf("%s/browse/%s", c.BaseURL, url.PathEscape(key))
+}
+
+// GetIssue returns the Jira issue with the given key.
+func (c *JiraClient) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if !jiraKeyPattern.MatchString(key) {
+		return nil, fmt.Errorf("%q is not a valid Jira key: %w", key, ErrIssueNotFound)
+	}
+
+	var issue jiraIssue
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,status", c.BaseURL, url.PathEscape(key))
+	if err := c.get(ctx, endpoint, &issue); err != nil {
+		return nil, err
+	}
+
+	return issue.toIssue(), nil
+}
+
+// SearchIssues returns the unresolved issues assigned to the current user that
+// match the text, most recently updated first.
+func (c *JiraClient) SearchIssues(ctx context.Context, text string) ([]Issue, error) {
+	jql := "assignee = currentUser() AND resolution = Unresolved"
+	if text = strings.TrimSpace(text); text != "" {
+		escaped := strings.ReplaceAll(text, `"`, `\"`)
+		if jiraKeyPattern.MatchString(strings.ToUpper(text)) {
+			jql += fmt.Sprintf(` AND (key = "%s" OR summary ~ "%s*")`, strings.ToUpper(escaped), escaped)
+		} else {
+			jql += fmt.Sprintf(` AND summary ~ "%s*"`, escaped)
+		}
+	}
+	jql += " ORDER BY updated DESC"
+
+	query := url.Values{}
+	query.Set("jql", jql)
+	query.Set("fields", "summary,status")
+	query.Set("maxResults", "20")
+
+	var result struct {
+		Issues []jiraIssue `json:"issues"`
+	}
+	if err := c.get(ctx, c.BaseURL+"/rest/api/2/search?"+query.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, len(result.Issues))
+	for i, issue := range result.Issues {
+		issues[i] = *issue.toIssue()
+	}
+
+	return issues, nil
+}
+
+// get performs an authenticated GET request and decodes the JSON response.
+func (c *JiraClient) get(ctx context.Context, endpoint string, target any) error {
+	request, err := helpers.NewJSONRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("Jira: %w", err)
+	}
+
+	switch {
+	case c.Email != "" && c.Token != "":
+		request.SetBasicAuth(c.Email, c.Token)
+	case c.Token != "":
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	return getJSON(c.HTTPClient, request, "Jira", c.Token != "", target)
+}
+
+// toIssue converts the Jira resource to an Issue.
+func (i jiraIssue) toIssue() *Issue {
+	return &Issue{Key: i.Key, Title: i.Fields.Summary, Status: i.Fields.Status.Name}
+}
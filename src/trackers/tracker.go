@@ -0,0 +1,164 @@
+package trackers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+)
+
+// lookupTimeout bounds every request to an issue tracker so the form stays
+// responsive when the tracker is slow or unreachable.
+const lookupTimeout = 3 * time.Second
+
+var (
+	// ErrIssueNotFound is returned when the tracker has no issue with the key.
+	ErrIssueNotFound = errors.New("issue not found")
+
+	// ErrUnavailable is returned when the tracker cannot be reached, e.g. when
+	// working offline. Callers should treat the reference as unverified rather
+	// than invalid.
+	ErrUnavailable = errors.New("issue tracker unavailable")
+)
+
+// forgeReferencePattern matches GitHub and GitLab issue references such as
+// "#123" or "group/project#12".
+var forgeReferencePattern = regexp.MustCompile(`^((?:[\w.-]+/)+[\w.-]+)?#([0-9]+)$`)
+
+// remoteProjectPattern extracts "owner/repo" or "group/sub/project" from SSH
+// and HTTPS remote URLs.
+var remoteProjectPattern = regexp.MustCompile(`^(?:[a-z+]+://)?(?:[^@/]+@)?[^:/]+(?::[0-9]+)?[:/](.+?)(?:\.git)?/?$`)
+
+// Issue is an issue as reported by a tracker.
+type Issue struct {
+	Key    string
+	Title  string
+	Status string
+}
+
+// Tracker looks up and searches issues in an issue tracker.
+type Tracker interface {
+	// Name returns the name of the tracker shown to the user.
+	Name() string
+
+	// ReferenceHint describes the reference syntax, e.g. "SS-1234" or "#123".
+	ReferenceHint() string
+
+	// ValidReference reports whether the reference has the tracker's syntax.
+	ValidReference(reference string) bool
+
+	// GetIssue returns the issue with the given key, ErrIssueNotFound when it
+	// does not exist or ErrUnavailable when the tracker cannot be reached.
+	GetIssue(ctx context.Context, key string) (*Issue, error)
+
+	// SearchIssues returns the open issues assigned to the current user whose
+	// key or title match the text. An empty text returns all of them.
+	SearchIssues(ctx context.Context, text string) ([]Issue, error)
+
+	// IssueURL returns the web page of the referenced issue, or an empty
+	// string when the reference does not have the tracker's syntax.
+	IssueURL(reference string) string
+}
+
+// New returns the tracker chosen in the config, or nil when it is not
+// configured. The remote URL is used to detect the GitHub or GitLab project
+// when the config does not name one.
+func New(config *settings.Config, remoteURL string) Tracker {
+	switch config.IssueTracker {
+	case settings.TrackerGitHub:
+		if github := NewGitHubClient(config.GitHub, remoteURL); github != nil {
+			return github
+		}
+	case settings.TrackerGitLab:
+		if gitlab := NewGitLabClient(config.GitLab, remoteURL); gitlab != nil {
+			return gitlab
+		}
+	default:
+		if jira := NewJiraClient(config.Jira); jira != nil {
+			return jira
+		}
+	}
+
+	return nil
+}
+
+// ClosingTrailer returns the line that closes the referenced issue when the
+// commit reaches the default branch, e.g. "Closes #123". It returns an empty
+// string when closing is disabled in the config or the tracker has no such
+// keyword.
+func ClosingTrailer(config *settings.Config, reference string) string {
+	reference = strings.TrimSpace(reference)
+	if !config.CloseIssues || reference == "" {
+		return ""
+	}
+
+	switch config.IssueTracker {
+	case settings.TrackerGitHub, settings.TrackerGitLab:
+		if forgeReferencePattern.MatchString(reference) {
+			return "Closes " + reference
+		}
+	}
+
+	return ""
+}
+
+// ProjectFromRemoteURL returns the project path, e.g. "owner/repo", from a git
+// remote URL, or an empty string if it cannot be determined.
+func ProjectFromRemoteURL(remoteURL string) string {
+	match := remoteProjectPattern.FindStringSubmatch(strings.TrimSpace(remoteURL))
+	if match == nil || !strings.Contains(match[1], "/") {
+		return ""
+	}
+	return match[1]
+}
+
+// WithTimeout returns a context for a single tracker request.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, lookupTimeout)
+}
+
+// splitForgeReference splits "group/project#12" into the project and issue
+// number. The project is empty for references like "#12".
+func splitForgeReference(reference string) (project string, number string, ok bool) {
+	match := forgeReferencePattern.FindStringSubmatch(strings.TrimSpace(reference))
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// getJSON sends the request and decodes the JSON response into target. The
+// service name is used in error messages. Network failures, rejected
+// credentials and server errors are reported as ErrUnavailable. A 404 is only
+// ErrIssueNotFound for authenticated requests, as trackers also answer 404 for
+// private or wrongly detected projects without credentials.
+func getJSON(client *http.Client, request *http.Request, service string, authenticated bool, target any) error {
+	err := helpers.SendJSON(client, request, service, target)
+	if errors.Is(err, helpers.ErrUnreachable) {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	var status *helpers.StatusError
+	if !errors.As(err, &status) {
+		return err
+	}
+
+	switch {
+	case status.StatusCode == http.StatusNotFound && authenticated:
+		return ErrIssueNotFound
+	case status.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s returned %s without credentials, the project may be private", ErrUnavailable, service, status.Status)
+	case status.StatusCode == http.StatusUnauthorized || status.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s rejected the credentials (%s)", ErrUnavailable, service, status.Status)
+	case status.StatusCode >= 500:
+		return fmt.Errorf("%w: %s returned %s", ErrUnavailable, service, status.Status)
+	}
+
+	return fmt.Errorf("unexpected %s response: %s", service, status.Status)
+}
@@ -1,11 +1,19 @@
 package feature_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kurianvarkey/gitcommitui/src/cmd"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
 	"github.com/stretchr/testify/require"
 )
 
@@ -96,12 +104,12 @@ func (m *MockForm) GetValues() (string, string, string, string) {
 	return "1.0", "feat", "JIRA-123", "Initial commit"
 }
 
-const testConfigFile = "config.json"
-
+// cleanupConfigFile removes the default config file that LoadConfig writes
+// into the test directory.
 func cleanupConfigFile(t *testing.T) {
 	t.Helper()
-	if _, err := os.Stat(testConfigFile); err == nil {
-		if err := os.Remove(testConfigFile); err != nil {
+	if _, err := os.Stat(settings.DefaultConfigFile); err == nil {
+		if err := os.Remove(settings.DefaultConfigFile); err != nil {
 			t.Fatalf("Failed to clean up config file: %v", err)
 		}
 	}
@@ -125,3 +133,478 @@ func TestFeatureRunAppSuccessfulFlow(t *testing.T) {
 	err := cmd.RunApp(mock, &MockForm{})
 	require.NoError(t, err)
 }
+
+// TestFeatureRunAmendSuccessfulFlow tests the amend mode with all underlying
+// calls succeeding and the user confirming every prompt.
+func TestFeatureRunAmendSuccessfulFlow(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+
+	err := cmd.RunAmend(mock, &MockForm{})
+	require.NoError(t, err)
+}
+
+// TestFeatureRunAppInvalidSigningMode tests that an unknown signing mode given
+// on the command line is rejected.
+func TestFeatureRunAppInvalidSigningMode(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+
+	err := cmd.RunApp(mock, &MockForm{}, cmd.WithSigning("sometimes", ""))
+	require.ErrorContains(t, err, "invalid signing mode")
+	require.Equal(t, cmd.ExitUsage, cmd.ExitCode(err))
+}
+
+// TestFeatureRunBranchesDone tests that the branch manager lists the branches
+// and returns when the user picks "Done".
+func TestFeatureRunBranchesDone(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	original := helpers.GetSelectPromptFunc()
+	defer helpers.SetSelectPromptFunc(original)
+
+	prompts := 0
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		prompts++
+		for _, option := range options {
+			if strings.TrimSpace(option.Label) == "Done" {
+				return option.Value, true
+			}
+		}
+		t.Fatalf("no Done option in %v", options)
+		return "", false
+	})
+
+	mock := &MockGitHelper{IsRepo: true}
+
+	err := cmd.RunBranches(mock)
+	require.NoError(t, err)
+	require.Equal(t, 1, prompts)
+}
+
+// CleanGitHelper is a MockGitHelper whose working tree has no changes.
+type CleanGitHelper struct {
+	MockGitHelper
+}
+
+func (m *CleanGitHelper) ExecuteCommand(cmd string) (string, error) {
+	if cmd == "git rev-parse --is-inside-work-tree" {
+		return "true", nil
+	}
+	return "", nil
+}
+
+// TestFeatureRunAppNothingToCommitOffersBranches tests that an interactive
+// run with a clean working tree offers the branch screen before the file
+// selection, and exits as having nothing to commit otherwise.
+func TestFeatureRunAppNothingToCommitOffersBranches(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	original := helpers.GetSelectPromptFunc()
+	defer helpers.SetSelectPromptFunc(original)
+
+	var titles []string
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		titles = append(titles, title)
+		for _, option := range options {
+			if label := strings.TrimSpace(option.Label); label == "Manage branches" || label == "Done" {
+				return option.Value, true
+			}
+		}
+		return "", false
+	})
+
+	mock := &CleanGitHelper{MockGitHelper{IsRepo: true}}
+	require.NoError(t, cmd.RunApp(mock, &MockForm{}))
+	require.Equal(t, []string{"There is nothing to commit.", "Branches"}, titles)
+
+	helpers.SetSelectPromptFunc(func(string, []helpers.SelectOption) (string, bool) { return "", false })
+	err := cmd.RunApp(mock, &MockForm{})
+	require.Equal(t, cmd.ExitNothingToCommit, cmd.ExitCode(err))
+}
+
+// TestFeatureRunAppNonInteractive tests a scripted commit: the values come
+// from the flags form, the changes are staged as asked and nothing is pushed
+// without the push flag.
+func TestFeatureRunAppNonInteractive(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+	form := &handlers.StaticCommitForm{CommitType: "feat", Summary: "Add login", Body: "With remember me"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive(cmd.StageAll, false))
+	require.NoError(t, err)
+}
+
+// TestFeatureRunAppNonInteractiveMissingSummary tests that a scripted commit
+// without a summary fails instead of prompting.
+func TestFeatureRunAppNonInteractiveMissingSummary(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+	form := &handlers.StaticCommitForm{CommitType: "feat"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive(cmd.StageNone, true))
+	require.ErrorContains(t, err, "missing summary")
+	require.Equal(t, cmd.ExitUsage, cmd.ExitCode(err))
+}
+
+// TestFeatureRunAppNonInteractiveInvalidStage tests that an unknown staging
+// mode is rejected.
+func TestFeatureRunAppNonInteractiveInvalidStage(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+	form := &handlers.StaticCommitForm{CommitType: "feat", Summary: "Add login"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive("some", false))
+	require.ErrorContains(t, err, "invalid stage")
+}
+
+// EventsGitHelper is a MockGitHelper whose HEAD moves when a commit is made,
+// as the JSON output reads the new SHA from it.
+type EventsGitHelper struct {
+	MockGitHelper
+	Head string
+}
+
+func (m *EventsGitHelper) ExecuteCommand(cmd string) (string, error) {
+	switch {
+	case cmd == "git rev-parse --verify HEAD":
+		return m.Head + "\n", nil
+	case strings.HasPrefix(cmd, "git commit"):
+		m.Head = "bbbbbbb"
+		return "", nil
+	case cmd == "git rev-parse --show-toplevel":
+		return "/repo\n", nil
+	case cmd == "git diff --cached --name-only":
+		return "login.go\n", nil
+	}
+	return "mocked output", nil
+}
+
+// decodeEvents returns the events written as newline-delimited JSON.
+func decodeEvents(t *testing.T, output string) []cmd.Event {
+	var events []cmd.Event
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var event cmd.Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+// TestFeatureRunAppEvents tests that a scripted commit writes an event for
+// each stage with the JSON output.
+func TestFeatureRunAppEvents(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	var output bytes.Buffer
+	mock := &EventsGitHelper{MockGitHelper: MockGitHelper{IsRepo: true}, Head: "aaaaaaa"}
+	form := &handlers.StaticCommitForm{CommitType: "feat", Summary: "Add login"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive(cmd.StageAll, false), cmd.WithEvents(&output))
+	require.NoError(t, err)
+
+	events := decodeEvents(t, output.String())
+	var names []string
+	for _, event := range events {
+		names = append(names, event.Event)
+	}
+	require.Equal(t, []string{
+		cmd.EventRepoDetected, cmd.EventFilesFound, cmd.EventFilesStaged, cmd.EventMessageComposed, cmd.EventCommitted,
+	}, names)
+	require.Equal(t, "/repo", events[0].Root)
+	require.Equal(t, []string{"login.go"}, events[2].Files)
+	require.Contains(t, events[3].Message, "Add login")
+	require.Equal(t, "bbbbbbb", events[4].SHA)
+}
+
+// TestFeatureRunAppEventsTextOutput tests that the text output of a push goes
+// to the output writer and not into the events.
+func TestFeatureRunAppEventsTextOutput(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	var events, text bytes.Buffer
+	mock := &EventsGitHelper{MockGitHelper: MockGitHelper{IsRepo: true}, Head: "aaaaaaa"}
+	form := &handlers.StaticCommitForm{CommitType: "feat", Summary: "Add login"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive(cmd.StageAll, true), cmd.WithEvents(&events), cmd.WithOutput(&text))
+	require.NoError(t, err)
+
+	decoded := decodeEvents(t, events.String())
+	require.Equal(t, cmd.EventPushResult, decoded[len(decoded)-1].Event)
+	require.Contains(t, text.String(), "Push summary:")
+	require.NotContains(t, events.String(), "Push summary:")
+}
+
+// TestFeatureRunAppEventsCommitFailed tests that a commit which did not move
+// HEAD is reported as failed with the JSON output.
+func TestFeatureRunAppEventsCommitFailed(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	var output bytes.Buffer
+	mock := &MockGitHelper{IsRepo: true}
+	form := &handlers.StaticCommitForm{CommitType: "feat", Summary: "Add login"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive(cmd.StageAll, false), cmd.WithEvents(&output))
+	require.Error(t, err)
+	require.Equal(t, cmd.CodeCommitFailed, cmd.ErrorCode(err))
+	require.Equal(t, cmd.ExitCommitFailed, cmd.ExitCode(err))
+	require.Equal(t, cmd.CodeError, cmd.ErrorCode(errors.New("some error")))
+}
+
+// TestFeatureRunAppCanceledExitCode tests that canceling the commit form
+// exits with the canceled code.
+func TestFeatureRunAppCanceledExitCode(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+	form := &MockForm{RunFunc: func() error { return errors.New("user aborted") }}
+
+	err := cmd.RunApp(mock, form)
+	require.ErrorIs(t, err, cmd.ErrCanceled)
+	require.Equal(t, cmd.ExitCanceled, cmd.ExitCode(err))
+}
+
+// DecliningGitHelper is a MockGitHelper whose confirmations are all
+// declined.
+type DecliningGitHelper struct {
+	MockGitHelper
+}
+
+func (m *DecliningGitHelper) ShowConfirm(title string, defaultValue ...bool) bool {
+	return false
+}
+
+// TestFeatureRunAppDeclinedInit tests that declining to initialise a
+// repository exits with the canceled code.
+func TestFeatureRunAppDeclinedInit(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	err := cmd.RunApp(&DecliningGitHelper{}, &MockForm{})
+	require.ErrorIs(t, err, handlers.ErrNotGitRepository)
+	require.Equal(t, cmd.ExitCanceled, cmd.ExitCode(err))
+}
+
+// TestFeatureRunAppDeclinedFiles tests that declining to commit the staged
+// files exits with the canceled code.
+func TestFeatureRunAppDeclinedFiles(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	err := cmd.RunApp(&DecliningGitHelper{MockGitHelper{IsRepo: true}}, &MockForm{})
+	require.ErrorIs(t, err, cmd.ErrCanceled)
+	require.Equal(t, cmd.ExitCanceled, cmd.ExitCode(err))
+}
+
+// TestFeatureExecuteInvalidFlagValues tests that invalid formats exit with the
+// usage code.
+func TestFeatureExecuteInvalidFlagValues(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &MockGitHelper{IsRepo: true}
+	for _, args := range [][]string{
+		{"changelog", "-format", "xml"},
+		{"report", "-format", "xml"},
+		{"stats", "-format", "xml"},
+	} {
+		err := cmd.Execute(mock, args)
+		require.ErrorContains(t, err, "invalid", args)
+		require.Equal(t, cmd.ExitUsage, cmd.ExitCode(err), args)
+	}
+}
+
+// disablePrompts disables the prompts as a scripted run does, restoring them
+// when the test ends.
+func disablePrompts(t *testing.T, yes bool) {
+	confirm, selectPrompt := helpers.GetConfirmPromptFunc(), helpers.GetSelectPromptFunc()
+	multiSelect, input := helpers.GetMultiSelectPromptFunc(), helpers.GetInputPromptFunc()
+
+	helpers.DisablePrompts(yes)
+	t.Cleanup(func() {
+		helpers.DisablePrompts(true)
+		helpers.SetConfirmPromptFunc(confirm)
+		helpers.SetSelectPromptFunc(selectPrompt)
+		helpers.SetMultiSelectPromptFunc(multiSelect)
+		helpers.SetInputPromptFunc(input)
+	})
+}
+
+// RepoGitHelper answers the repository check, so a scripted run gets past it
+// without initialising a repository.
+type RepoGitHelper struct {
+	EventsGitHelper
+}
+
+func (m *RepoGitHelper) ExecuteCommand(cmd string) (string, error) {
+	if cmd == "git rev-parse --is-inside-work-tree" {
+		return "true", nil
+	}
+	return m.EventsGitHelper.ExecuteCommand(cmd)
+}
+
+// TestFeatureRunAppPullRequestNeedsYes tests that a scripted push with pull
+// requests enabled fails with the usage code unless -yes is given.
+func TestFeatureRunAppPullRequestNeedsYes(t *testing.T) {
+	defer settings.SetConfigFile("")
+	config := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"commit_format": "$type: $summary", "pull_request": {"enabled": true}}`), 0644))
+	settings.SetConfigFile(config)
+
+	disablePrompts(t, false)
+
+	mock := &RepoGitHelper{EventsGitHelper{MockGitHelper: MockGitHelper{IsRepo: true}, Head: "aaaaaaa"}}
+	form := &handlers.StaticCommitForm{CommitType: "feat", Summary: "Add login"}
+
+	err := cmd.RunApp(mock, form, cmd.WithNonInteractive(cmd.StageAll, true))
+	require.ErrorIs(t, err, helpers.ErrConfirmationRequired)
+	require.ErrorContains(t, err, "open a pull request")
+	require.Equal(t, cmd.ExitUsage, cmd.ExitCode(err))
+}
+
+// TestFeatureExitCode tests the exit codes of errors of each kind and of
+// errors of no known kind.
+func TestFeatureExitCode(t *testing.T) {
+	require.Equal(t, cmd.ExitOK, cmd.ExitCode(nil))
+	require.Equal(t, cmd.ExitInternal, cmd.ExitCode(errors.New("some error")))
+	require.Equal(t, cmd.ExitCanceled, cmd.ExitCode(handlers.ErrForcePushCanceled))
+	require.Equal(t, cmd.ExitNothingToCommit, cmd.ExitCode(fmt.Errorf("wrapped: %w", cmd.ErrNothingToCommit)))
+	require.Equal(t, cmd.ExitPushFailed, cmd.ExitCode(cmd.ErrPushFailed))
+	require.Equal(t, cmd.ExitUsage, cmd.ExitCode(cmd.Execute(&MockGitHelper{IsRepo: true}, []string{"bogus"})))
+
+	defer settings.SetConfigFile("")
+	config := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"bogus": true}`), 0644))
+
+	err := cmd.Execute(&MockGitHelper{IsRepo: true}, []string{"-config", config, "config", "validate"})
+	require.Equal(t, cmd.ExitConfig, cmd.ExitCode(err))
+}
+
+// ProtectedGitHelper is a MockGitHelper on the protected main branch.
+type ProtectedGitHelper struct {
+	MockGitHelper
+}
+
+func (m *ProtectedGitHelper) ExecuteCommand(cmd string) (string, error) {
+	if cmd == "git rev-parse --abbrev-ref HEAD" {
+		return "main\n", nil
+	}
+	return m.MockGitHelper.ExecuteCommand(cmd)
+}
+
+// TestFeatureRunFixupProtectedBranch tests that fixup commits are refused on
+// a protected branch.
+func TestFeatureRunFixupProtectedBranch(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &ProtectedGitHelper{MockGitHelper{IsRepo: true}}
+
+	err := cmd.RunFixup(mock, handlers.FixupCommit)
+	require.ErrorContains(t, err, "'main' is a protected branch")
+}
+
+// RecordingGitHelper records the commands it runs.
+type RecordingGitHelper struct {
+	MockGitHelper
+	Executed []string
+}
+
+func (m *RecordingGitHelper) ExecuteCommand(cmd string) (string, error) {
+	m.Executed = append(m.Executed, cmd)
+	if cmd == "git rev-parse --is-inside-work-tree" {
+		return "true", nil
+	}
+	return m.MockGitHelper.ExecuteCommand(cmd)
+}
+
+// TestFeatureRunFixupListsUnpushedCommits tests that the fixup picker only
+// reads the commits of the branch that are not in its upstream.
+func TestFeatureRunFixupListsUnpushedCommits(t *testing.T) {
+	defer cleanupConfigFile(t)
+
+	mock := &RecordingGitHelper{MockGitHelper: MockGitHelper{IsRepo: true}}
+
+	err := cmd.RunFixup(mock, handlers.FixupCommit)
+	require.ErrorContains(t, err, "no commits to fixup that are not pushed yet")
+
+	var logCommand string
+	for _, executed := range mock.Executed {
+		if strings.HasPrefix(executed, "git log") {
+			logCommand = executed
+		}
+	}
+	require.True(t, strings.HasSuffix(logCommand, " @{upstream}..HEAD"), logCommand)
+}
+
+// TestFeatureExecuteFormatOnlyOnItsCommands tests that -format is a flag of
+// the changelog, report and stats commands and not of the commit command.
+func TestFeatureExecuteFormatOnlyOnItsCommands(t *testing.T) {
+	mock := &MockGitHelper{IsRepo: true}
+
+	for _, args := range [][]string{
+		{"-format", "json"},
+		{"commit", "-format", "json"},
+		{"-format", "json", "changelog"},
+	} {
+		err := cmd.Execute(mock, args)
+		require.ErrorContains(t, err, "flag provided but not defined: -format", args)
+		require.Equal(t, cmd.ExitUsage, cmd.ExitCode(err), args)
+	}
+}
+
+// TestFeatureExecuteHelpAndVersion tests that help and version run without
+// touching the repository.
+func TestFeatureExecuteHelpAndVersion(t *testing.T) {
+	mock := &MockGitHelper{IsRepo: true}
+
+	require.NoError(t, cmd.Execute(mock, []string{"help"}))
+	require.NoError(t, cmd.Execute(mock, []string{"help", "config", "init"}))
+	require.NoError(t, cmd.Execute(mock, []string{"lint", "-h"}))
+	require.NoError(t, cmd.Execute(mock, []string{"version"}))
+}
+
+// TestFeatureExecuteUnknownCommand tests that unknown commands and unexpected
+// arguments are rejected before anything runs.
+func TestFeatureExecuteUnknownCommand(t *testing.T) {
+	mock := &MockGitHelper{IsRepo: true}
+
+	require.ErrorContains(t, cmd.Execute(mock, []string{"bogus"}), `unknown command "bogus"`)
+	require.ErrorContains(t, cmd.Execute(mock, []string{"config", "bogus"}), `unknown command "config bogus"`)
+	require.ErrorContains(t, cmd.Execute(mock, []string{"config"}), "missing subcommand")
+	require.ErrorContains(t, cmd.Execute(mock, []string{"version", "extra"}), "unexpected arguments")
+	require.ErrorContains(t, cmd.Execute(mock, []string{"help", "bogus"}), `unknown command "bogus"`)
+	require.Error(t, cmd.Execute(mock, []string{"-bogus"}))
+	require.ErrorContains(t, cmd.Execute(mock, []string{"-output", "xml", "version"}), "invalid output")
+}
+
+// TestFeatureExecuteConfigAndLint tests the config init, config validate and
+// lint commands with a config file given by the global flag.
+func TestFeatureExecuteConfigAndLint(t *testing.T) {
+	defer settings.SetConfigFile("")
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.json")
+	mock := &MockGitHelper{IsRepo: true}
+
+	require.NoError(t, cmd.Execute(mock, []string{"-config", config, "config", "init"}))
+	require.FileExists(t, config)
+	require.NoError(t, cmd.Execute(mock, []string{"config", "validate", "-config", config}))
+
+	message := filepath.Join(dir, "COMMIT_EDITMSG")
+	require.NoError(t, os.WriteFile(message, []byte("[1.x][feat][SS-1]: Add login\n"), 0644))
+	require.NoError(t, cmd.Execute(mock, []string{"-config", config, "lint", "-message-file", message}))
+
+	require.NoError(t, os.WriteFile(message, []byte("Add login\n"), 0644))
+	require.ErrorContains(t, cmd.Execute(mock, []string{"-config", config, "lint", "-message-file", message}), "does not follow")
+}
+
+// TestFeatureRunStashWritesToOutput tests that the stash screen writes its
+// text to the given output.
+func TestFeatureRunStashWritesToOutput(t *testing.T) {
+	var text bytes.Buffer
+	err := cmd.RunStash(&CleanGitHelper{}, cmd.WithOutput(&text))
+	require.NoError(t, err)
+	require.Equal(t, "There are no stashes\n", text.String())
+}
@@ -0,0 +1,123 @@
+package forges_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/forges"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitHubStandIn is a local HTTP server that behaves like the parts of the
+// GitHub REST API used to open pull requests.
+type gitHubStandIn struct {
+	*httptest.Server
+	open      []string       // JSON pull requests returned for the head branch
+	created   map[string]any // body of the created pull request
+	reviewers []any
+	labels    []any
+}
+
+func newGitHubStandIn(t *testing.T) *gitHubStandIn {
+	t.Helper()
+
+	standIn := &gitHubStandIn{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"default_branch":"main"}`))
+	})
+	mux.HandleFunc("GET /repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "owner:feature", r.URL.Query().Get("head"))
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		w.Write([]byte("[" + strings.Join(standIn.open, ",") + "]"))
+	})
+	mux.HandleFunc("POST /repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&standIn.created))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number":42,"html_url":"https://github.com/owner/repo/pull/42"}`))
+	})
+	mux.HandleFunc("POST /repos/owner/repo/pulls/42/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		standIn.reviewers = body["reviewers"]
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("POST /repos/owner/repo/issues/42/labels", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		standIn.labels = body["labels"]
+		w.Write([]byte(`[]`))
+	})
+
+	standIn.Server = httptest.NewServer(mux)
+	t.Cleanup(standIn.Close)
+
+	return standIn
+}
+
+func newTestGitHubForge(t *testing.T, baseURL string) forges.Forge {
+	t.Helper()
+	t.Setenv("GITHUB_TOKEN", "")
+
+	config := &settings.Config{
+		GitHub:      settings.Forge{BaseURL: baseURL, Token: "secret"},
+		PullRequest: settings.PullRequest{Forge: settings.TrackerGitHub},
+	}
+
+	forge, err := forges.New(config, "git@github.com:owner/repo.git")
+	require.NoError(t, err)
+	return forge
+}
+
+func TestGitHubCreatePullRequest(t *testing.T) {
+	standIn := newGitHubStandIn(t)
+	forge := newTestGitHubForge(t, standIn.URL)
+
+	pullRequest, err := forge.FindOrCreatePullRequest(context.Background(), forges.PullRequestOptions{
+		Head:      "feature",
+		Title:     "Add login",
+		Body:      "- Add login",
+		Reviewers: []string{"jane"},
+		Labels:    []string{"feature"},
+		Draft:     true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &forges.PullRequest{Number: 42, URL: "https://github.com/owner/repo/pull/42"}, pullRequest)
+	assert.Equal(t, map[string]any{"title": "Add login", "head": "feature", "base": "main", "body": "- Add login", "draft": true}, standIn.created)
+	assert.Equal(t, []any{"jane"}, standIn.reviewers)
+	assert.Equal(t, []any{"feature"}, standIn.labels)
+}
+
+func TestGitHubFindExistingPullRequest(t *testing.T) {
+	standIn := newGitHubStandIn(t)
+	standIn.open = []string{`{"number":7,"html_url":"https://github.com/owner/repo/pull/7"}`}
+	forge := newTestGitHubForge(t, standIn.URL)
+
+	pullRequest, err := forge.FindOrCreatePullRequest(context.Background(), forges.PullRequestOptions{Head: "feature", Title: "Add login"})
+	require.NoError(t, err)
+
+	assert.Equal(t, &forges.PullRequest{Number: 7, URL: "https://github.com/owner/repo/pull/7", Existing: true}, pullRequest)
+	assert.Nil(t, standIn.created)
+}
+
+func TestGitHubPullRequestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	forge := newTestGitHubForge(t, server.URL)
+
+	_, err := forge.FindOrCreatePullRequest(context.Background(), forges.PullRequestOptions{Head: "feature"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Bad credentials")
+}
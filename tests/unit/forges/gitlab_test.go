@@ -0,0 +1,109 @@
+package forges_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/forges"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitLabStandIn starts a local HTTP server that behaves like the parts of
+// the GitLab REST API used to open merge requests. The created merge request
+// body is stored in created.
+func newGitLabStandIn(t *testing.T, created *map[string]any) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/group%2Fproject", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"default_branch":"develop"}`))
+	})
+	mux.HandleFunc("GET /api/v4/projects/group%2Fproject/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "feature", r.URL.Query().Get("source_branch"))
+		assert.Equal(t, "opened", r.URL.Query().Get("state"))
+		w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("GET /api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "jane", r.URL.Query().Get("username"))
+		w.Write([]byte(`[{"id":5}]`))
+	})
+	mux.HandleFunc("POST /api/v4/projects/group%2Fproject/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(created))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"iid":3,"web_url":"https://gitlab.example.com/group/project/-/merge_requests/3"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGitLabCreateMergeRequest(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "")
+
+	var created map[string]any
+	server := newGitLabStandIn(t, &created)
+
+	config := &settings.Config{GitLab: settings.Forge{BaseURL: server.URL, Token: "secret"}}
+	forge, err := forges.New(config, "git@gitlab.example.com:group/project.git")
+	require.NoError(t, err)
+	assert.Equal(t, "GitLab", forge.Name())
+
+	pullRequest, err := forge.FindOrCreatePullRequest(context.Background(), forges.PullRequestOptions{
+		Head:      "feature",
+		Title:     "Add login",
+		Body:      "- Add login",
+		Reviewers: []string{"@jane"},
+		Labels:    []string{"feature", "ui"},
+		Draft:     true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &forges.PullRequest{Number: 3, URL: "https://gitlab.example.com/group/project/-/merge_requests/3"}, pullRequest)
+	assert.Equal(t, map[string]any{
+		"source_branch": "feature",
+		"target_branch": "develop",
+		"title":         "Draft: Add login",
+		"description":   "- Add login",
+		"labels":        "feature,ui",
+		"reviewer_ids":  []any{float64(5)},
+	}, created)
+}
+
+func TestNewForgeDetection(t *testing.T) {
+	config := &settings.Config{}
+
+	forge, err := forges.New(config, "https://github.com/owner/repo.git")
+	require.NoError(t, err)
+	assert.Equal(t, "GitHub", forge.Name())
+
+	config.GitLab.BaseURL = "https://code.example.com"
+	forge, err = forges.New(config, "git@code.example.com:group/project.git")
+	require.NoError(t, err)
+	assert.Equal(t, "GitLab", forge.Name())
+
+	config.GitHub.BaseURL = "https://ghe.example.com/api/v3"
+	forge, err = forges.New(config, "git@ghe.example.com:owner/repo.git")
+	require.NoError(t, err)
+	assert.Equal(t, "GitHub", forge.Name())
+
+	_, err = forges.New(&settings.Config{}, "/srv/repo.git")
+	assert.ErrorIs(t, err, forges.ErrUnknownForge)
+
+	_, err = forges.New(&settings.Config{}, "git@bitbucket.org:owner/repo.git")
+	assert.ErrorIs(t, err, forges.ErrUnknownForge)
+
+	forge, err = forges.New(&settings.Config{PullRequest: settings.PullRequest{Forge: settings.TrackerGitHub}}, "git@code.example.com:owner/repo.git")
+	require.NoError(t, err)
+	assert.Equal(t, "GitHub", forge.Name())
+
+	_, err = forges.New(&settings.Config{PullRequest: settings.PullRequest{Forge: "bitbucket"}}, "https://github.com/owner/repo.git")
+	assert.Error(t, err)
+}
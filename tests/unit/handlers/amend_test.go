@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// amend.go methods
+func TestGetLastCommitMessage(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, commands.GitLastCommitMessage, cmd)
+			return "[1.x][feat][SS-1]: Add thing\n\n", nil
+		},
+	}
+
+	message, err := handlers.GetLastCommitMessage(mock)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1.x][feat][SS-1]: Add thing", message)
+}
+
+func TestGetLastCommitMessageNoCommits(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "", errors.New("does not have any commits yet")
+		},
+	}
+
+	_, err := handlers.GetLastCommitMessage(mock)
+	assert.Error(t, err)
+}
+
+func TestIsHeadPushed(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == commands.GitUpstream {
+				return "origin/main\n", nil
+			}
+			return "", nil
+		},
+	}
+
+	upstream, pushed := handlers.IsHeadPushed(mock)
+	assert.Equal(t, "origin/main", upstream)
+	assert.True(t, pushed)
+
+	mock.ExecuteCommandFunc = func(cmd string) (string, error) {
+		if cmd == commands.GitUpstream {
+			return "origin/main\n", nil
+		}
+		return "", errors.New("exit status 1")
+	}
+
+	_, pushed = handlers.IsHeadPushed(mock)
+	assert.False(t, pushed)
+}
+
+func TestPrefillAmendForm(t *testing.T) {
+	config := &settings.Config{CommitFormat: "[$version][$type][$jira]: $summary", DefaultCommitType: "feat"}
+	form := &handlers.DefaultCommitForm{}
+
+	ok := handlers.PrefillAmendForm(config, form, "[2.0][fix][SS-5]: Fix bug")
+	assert.True(t, ok)
+
+	version, commitType, jira, summary := form.GetValues()
+	assert.Equal(t, "2.0", version)
+	assert.Equal(t, "fix", commitType)
+	assert.Equal(t, "SS-5", jira)
+	assert.Equal(t, "Fix bug", summary)
+
+	ok = handlers.PrefillAmendForm(config, form, "Some free text")
+	assert.False(t, ok)
+	_, commitType, _, summary = form.GetValues()
+	assert.Equal(t, "feat", commitType)
+	assert.Equal(t, "Some free text", summary)
+}
+
+func TestPrefillAmendFormKeepsTrailerBlock(t *testing.T) {
+	config := &settings.Config{CommitFormat: "$type: $summary"}
+	message := "feat: Pair work\n\nCo-authored-by: Jane Doe <jane@example.com>\nSigned-off-by: Sam Lee <sam@example.com>"
+
+	form := &handlers.DefaultCommitForm{}
+	assert.True(t, handlers.PrefillAmendForm(config, form, message))
+
+	_, _, _, summary := form.GetValues()
+	assert.Equal(t, "Pair work", summary)
+	assert.Equal(t, message, handlers.ComposeCommitMessage(config, form))
+}
+
+func TestAmendRoundTripWritesEachTrailerOnce(t *testing.T) {
+	config := &settings.Config{CommitFormat: "$type($jira): $summary", IssueTracker: settings.TrackerGitHub, CloseIssues: true}
+	message := "feat(#12): Add login\n\nCloses #12\nCo-authored-by: Jane Doe <jane@example.com>\nSigned-off-by: Sam Lee <sam@example.com>"
+
+	form := &handlers.DefaultCommitForm{}
+	assert.True(t, handlers.PrefillAmendForm(config, form, message))
+	_, _, _, summary := form.GetValues()
+	assert.Equal(t, "Add login", summary)
+
+	amended := handlers.ComposeCommitMessage(config, form)
+	for _, trailer := range []string{"Closes #12", "Co-authored-by: Jane Doe <jane@example.com>", "Signed-off-by: Sam Lee <sam@example.com>"} {
+		assert.Equal(t, 1, strings.Count(amended, trailer), trailer)
+	}
+	assert.Equal(t, message, amended)
+}
+
+func TestShowAmendUI(t *testing.T) {
+	config := &settings.Config{CommitFormat: "$type: $summary"}
+	form := &MockForm{}
+
+	var executed string
+	helper := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = cmd
+			return "", nil
+		},
+	}
+
+	assert.NoError(t, handlers.ShowAmendUI(helper, config, form, false))
+	assert.Equal(t, "git commit --amend --only -m 'feat: Initial commit'", executed)
+
+	assert.NoError(t, handlers.ShowAmendUI(helper, config, form, true))
+	assert.Equal(t, "git commit --amend -m 'feat: Initial commit'", executed)
+}
+
+func TestShowAmendUIProtectedBranch(t *testing.T) {
+	config := &settings.Config{CommitFormat: "$type: $summary", ProtectedBranches: []string{"main"}}
+	form := &MockForm{}
+
+	var executed, confirmed []string
+	helper := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			if cmd == "git rev-parse --abbrev-ref HEAD" {
+				return "main\n", nil
+			}
+			return "", nil
+		},
+		ShowConfirmFunc: func(message string, defaultYes ...bool) bool {
+			confirmed = append(confirmed, message)
+			return true
+		},
+	}
+
+	assert.NoError(t, handlers.ShowAmendUI(helper, config, form, false))
+	assert.Equal(t, []string{"git rev-parse --abbrev-ref HEAD", "git switch -c feat/JIRA-123-initial-commit", "git commit --amend --only -m 'feat: Initial commit'"}, executed)
+	assert.Len(t, confirmed, 2)
+	assert.Contains(t, confirmed[0], "'main' is a protected branch")
+	assert.Contains(t, confirmed[1], "Amend last commit")
+}
@@ -0,0 +1,142 @@
+package handlers_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// branches.go methods
+const mockBranchesOutput = "refs/heads/main\x1fmain\x1faaaa111\x1f2 hours ago\x1fJane Doe\x1fAdd login\n" +
+	"refs/remotes/origin/HEAD\x1forigin\x1faaaa111\x1f2 hours ago\x1fJane Doe\x1fAdd login\n" +
+	"refs/remotes/origin/feat/search\x1forigin/feat/search\x1fbbbb222\x1f3 days ago\x1fJohn Roe\x1fAdd search\n"
+
+func TestGetBranches(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git rev-parse --abbrev-ref HEAD" {
+				return "main\n", nil
+			}
+			assert.True(t, strings.HasPrefix(cmd, "git for-each-ref --sort=-committerdate"))
+			return mockBranchesOutput, nil
+		},
+	}
+
+	branches, err := handlers.GetBranches(mock)
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+
+	assert.True(t, branches[0].Current)
+	assert.Equal(t, "* main  aaaa111  2 hours ago  Jane Doe: Add login", branches[0].Label())
+
+	assert.True(t, branches[1].Remote)
+	assert.Equal(t, "feat/search", branches[1].LocalName())
+}
+
+func TestSwitchBranch(t *testing.T) {
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			return "", nil
+		},
+	}
+
+	main := handlers.Branch{Name: "main"}
+	remoteMain := handlers.Branch{Name: "origin/main", Remote: true}
+	remoteSearch := handlers.Branch{Name: "origin/feat/search", Remote: true}
+	branches := []handlers.Branch{main, remoteMain, remoteSearch}
+
+	require.NoError(t, handlers.SwitchBranch(mock, remoteMain, branches, handlers.CarryChanges))
+	require.NoError(t, handlers.SwitchBranch(mock, remoteSearch, branches, handlers.StashChanges))
+
+	assert.Equal(t, []string{
+		"git switch main",
+		"git stash push --include-untracked -m 'Before switching to feat/search'",
+		"git switch --track origin/feat/search",
+	}, executed)
+}
+
+func TestCreateBranch(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git switch -c 'feat/login' origin/main", cmd)
+			return "fatal: a branch named 'feat/login' already exists", errors.New("exit status 128")
+		},
+	}
+
+	err := handlers.CreateBranch(mock, "feat/login", "origin/main", handlers.CarryChanges)
+	assert.Error(t, err)
+}
+
+func TestValidateBranchName(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git check-ref-format --branch 'feat/login'" {
+				return "feat/login", nil
+			}
+			return "fatal: 'feat..login' is not a valid branch name", errors.New("exit status 128")
+		},
+	}
+
+	assert.NoError(t, handlers.ValidateBranchName(mock, "feat/login"))
+	assert.ErrorContains(t, handlers.ValidateBranchName(mock, "feat..login"), "not a valid branch name")
+}
+
+func TestAskUncommittedChanges(t *testing.T) {
+	clean := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) { return "", nil },
+	}
+	changes, err := handlers.AskUncommittedChanges(clean, "main")
+	require.NoError(t, err)
+	assert.Equal(t, handlers.CarryChanges, changes)
+
+	dirty := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) { return " M main.go\n", nil },
+	}
+
+	mockSelectPrompt(t, handlers.StashChanges, "")
+	changes, err = handlers.AskUncommittedChanges(dirty, "main")
+	require.NoError(t, err)
+	assert.Equal(t, handlers.StashChanges, changes)
+
+	_, err = handlers.AskUncommittedChanges(dirty, "main")
+	assert.ErrorIs(t, err, handlers.ErrSwitchCanceled)
+}
+
+func TestMergedBranches(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git rev-parse --abbrev-ref HEAD" {
+				return "develop\n", nil
+			}
+			assert.Equal(t, "git branch --merged develop --format=%(refname:short)", cmd)
+			return "develop\nmain\nfeat/login\nfix/crash\n", nil
+		},
+	}
+
+	config := &settings.Config{ProtectedBranches: []string{"main"}}
+	merged, err := handlers.MergedBranches(mock, config, "develop")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat/login", "fix/crash"}, merged)
+}
+
+func TestDeleteBranches(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git branch -d fix/crash" {
+				return "error: the branch 'fix/crash' is not fully merged", errors.New("exit status 1")
+			}
+			return "", nil
+		},
+	}
+
+	results := handlers.DeleteBranches(mock, []string{"feat/login", "fix/crash"})
+	assert.NoError(t, results["feat/login"])
+	assert.Error(t, results["fix/crash"])
+}
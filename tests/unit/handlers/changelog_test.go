@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changelog.go methods
+func newChangelogConfig() *settings.Config {
+	config := newReleaseConfig()
+	config.Changelog = settings.Changelog{
+		Sections: []settings.ChangelogSection{
+			{Type: "feat", Title: "Features"},
+			{Type: "fix", Title: "Bug Fixes"},
+		},
+	}
+	return config
+}
+
+// newChangelogTracker returns a Jira tracker linking references to
+// jira.example.com.
+func newChangelogTracker() trackers.Tracker {
+	return &trackers.JiraClient{BaseURL: "https://jira.example.com"}
+}
+
+func TestBuildChangelog(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Contains(t, cmd, "v1.2.0..v1.3.0")
+			return mockReleaseLog(
+				"[1.x][fix][SS-2]: Fix crash",
+				"[1.x][chore][]: Bump deps",
+				"[1.x][feat][SS-1]: Add login\n\nWith remember me",
+				"Merge branch 'main'",
+			), nil
+		},
+	}
+
+	changelog, err := handlers.BuildChangelog(mock, newChangelogConfig(), newChangelogTracker(), "v1.2.0", "v1.3.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.3.0", changelog.Title)
+	require.Len(t, changelog.Sections, 2)
+	assert.Equal(t, "Features", changelog.Sections[0].Title)
+	assert.Equal(t, "https://jira.example.com/browse/SS-1", changelog.Sections[0].Entries[0].ReferenceURL)
+
+	assert.Equal(t, "## v1.3.0 (2025-01-02)\n\n"+
+		"### Features\n\n- Add login ([SS-1](https://jira.example.com/browse/SS-1))\n\n"+
+		"### Bug Fixes\n\n- Fix crash ([SS-2](https://jira.example.com/browse/SS-2))\n", changelog.Markdown())
+
+	data, err := changelog.JSON()
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "v1.2.0", decoded["from"])
+	assert.Len(t, decoded["sections"], 2)
+}
+
+func TestBuildChangelogDefaultSections(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return mockReleaseLog("[1.x][fix][SS-2]: Fix crash", "[1.x][chore][]: Bump deps"), nil
+		},
+	}
+
+	config := newChangelogConfig()
+	config.Changelog.Sections = nil
+
+	changelog, err := handlers.BuildChangelog(mock, config, newChangelogTracker(), "v1.2.0", "v1.3.0")
+	require.NoError(t, err)
+	require.Len(t, changelog.Sections, 1)
+	assert.Equal(t, "Bug Fixes", changelog.Sections[0].Title)
+}
+
+func TestBuildChangelogSinceLastTag(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git describe --tags --abbrev=0 --match v*" {
+				return "v1.3.0\n", nil
+			}
+			assert.Contains(t, cmd, "v1.3.0..HEAD")
+			return "", nil
+		},
+	}
+
+	changelog, err := handlers.BuildChangelog(mock, newChangelogConfig(), nil, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "## Unreleased\n\nNo notable changes.\n", changelog.Markdown())
+}
+
+func TestWriteChangelog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	require.NoError(t, handlers.WriteChangelog(path, "## v1.0.0\n\n- First\n"))
+	require.NoError(t, handlers.WriteChangelog(path, "## v1.1.0\n\n- Second\n"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## v1.1.0\n\n- Second\n\n## v1.0.0\n\n- First\n", string(content))
+}
+
+func TestWriteChangelogReplacesVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	require.NoError(t, handlers.WriteChangelog(path, "## v1.0.0 (2025-01-01)\n\n- First\n"))
+	require.NoError(t, handlers.WriteChangelog(path, "## v1.1.0 (2025-01-02)\n\n- Second\n"))
+	require.NoError(t, handlers.WriteChangelog(path, "## v1.1.0 (2025-01-02)\n\n- Second\n"))
+	require.NoError(t, handlers.WriteChangelog(path, "## v1.0.0 (2025-01-01)\n\n- First\n- Again\n"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## v1.1.0 (2025-01-02)\n\n- Second\n\n## v1.0.0 (2025-01-01)\n\n- First\n- Again\n", string(content))
+}
+
+func TestChangelogFile(t *testing.T) {
+	assert.Equal(t, "CHANGELOG.md", handlers.ChangelogFile(&settings.Config{}))
+	assert.Equal(t, "docs/CHANGES.md", handlers.ChangelogFile(&settings.Config{Changelog: settings.Changelog{File: "docs/CHANGES.md"}}))
+}
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// coauthors.go methods
+func TestGetCoAuthorCandidates(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			switch cmd {
+			case "git config --get user.email":
+				return "me@example.com\n", nil
+			case commands.GitShortlog:
+				return "    12\tMe Myself <me@example.com>\n     7\tJane Doe <JANE@example.com>\n     3\tdependabot[bot] <bot@users.noreply.github.com>\n", nil
+			}
+			return "", errors.New("unexpected command")
+		},
+	}
+
+	config := &settings.Config{TeamRoster: []string{"Jane Doe <jane@example.com>", "Sam Lee <sam@example.com>", "not an author"}}
+
+	candidates := handlers.GetCoAuthorCandidates(mock, config)
+	assert.Equal(t, []string{
+		"Jane Doe <jane@example.com>",
+		"Sam Lee <sam@example.com>",
+		"dependabot[bot] <bot@users.noreply.github.com>",
+	}, candidates)
+}
+
+func TestCoAuthorTrailers(t *testing.T) {
+	trailers := handlers.CoAuthorTrailers([]string{" Jane Doe  <jane@example.com>", "invalid"})
+	assert.Equal(t, []string{"Co-authored-by: Jane Doe <jane@example.com>"}, trailers)
+}
+
+func TestShowCommitUIWithCoAuthors(t *testing.T) {
+	form := &coAuthorMockForm{}
+	form.SetCoAuthors(nil, []string{"Jane Doe <jane@example.com>", "Sam Lee <sam@example.com>"})
+
+	var executed string
+	helper := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = cmd
+			return "", nil
+		},
+	}
+
+	config := &settings.Config{CommitFormat: "$type: $summary"}
+	assert.NoError(t, handlers.ShowCommitUI(helper, config, form))
+	assert.Equal(t, "git commit -m 'feat: Initial commit\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: Sam Lee <sam@example.com>'", executed)
+}
+
+func TestParseCommitMessageWithCoAuthors(t *testing.T) {
+	config := &settings.Config{CommitFormat: "$type: $summary"}
+
+	parsed, ok := handlers.ParseCommitMessage(config, "feat: Pair work\n\nCo-authored-by: Jane Doe <jane@example.com>\n")
+	assert.True(t, ok)
+	assert.Equal(t, "Pair work", parsed.Summary)
+	assert.Equal(t, []string{"Jane Doe <jane@example.com>"}, parsed.CoAuthors)
+
+	parsed, ok = handlers.ParseCommitMessage(config, "feat: Pair work\n\nCo-authored-by: Jane Doe <jane@example.com>\nSigned-off-by: Sam Lee <sam@example.com>\n")
+	assert.True(t, ok)
+	assert.Equal(t, "Pair work", parsed.Summary)
+	assert.Equal(t, []string{"Jane Doe <jane@example.com>"}, parsed.CoAuthors)
+	assert.Equal(t, []string{"Signed-off-by: Sam Lee <sam@example.com>"}, parsed.Trailers)
+
+	parsed, ok = handlers.ParseCommitMessage(config, "feat: Pair work\n\nNote: not a trailer block\nsecond line\n")
+	assert.True(t, ok)
+	assert.Equal(t, "Pair work\n\nNote: not a trailer block\nsecond line", parsed.Summary)
+	assert.Empty(t, parsed.Trailers)
+}
+
+// coAuthorMockForm is a mock form that also reports selected co-authors.
+type coAuthorMockForm struct {
+	MockForm
+	coAuthors []string
+}
+
+func (m *coAuthorMockForm) SetCoAuthors(candidates []string, selected []string) {
+	m.coAuthors = selected
+}
+
+func (m *coAuthorMockForm) GetCoAuthors() []string {
+	return m.coAuthors
+}
+
+func TestShowCommitUIEscapesCoAuthorNames(t *testing.T) {
+	form := &coAuthorMockForm{}
+	form.SetCoAuthors(nil, []string{"Sean O'Brien <sean@example.com>"})
+
+	var executed string
+	helper := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = cmd
+			return "", nil
+		},
+	}
+
+	config := &settings.Config{CommitFormat: "$type: $summary"}
+	assert.NoError(t, handlers.ShowCommitUI(helper, config, form))
+
+	args, err := helpers.ParseCommand(executed)
+	assert.NoError(t, err)
+	assert.Equal(t, "feat: Initial commit\n\nCo-authored-by: Sean O'Brien <sean@example.com>", args[len(args)-1])
+}
@@ -58,8 +58,7 @@ func TestShowCommitUISuccess(t *testing.T) {
 		CommitFormat: "$version-$type-$jira-$summary",
 	}
 
-	confirmed := handlers.ShowCommitUI(helper, config, form)
-	assert.True(t, confirmed)
+	assert.NoError(t, handlers.ShowCommitUI(helper, config, form))
 }
 
 func TestShowCommitUIFormCancelled(t *testing.T) {
@@ -69,7 +68,72 @@ func TestShowCommitUIFormCancelled(t *testing.T) {
 
 	helper := &MockGitHelper{}
 	config := &settings.Config{}
-	confirmed := handlers.ShowCommitUI(helper, config, form)
+	err := handlers.ShowCommitUI(helper, config, form)
 
-	assert.False(t, confirmed)
+	assert.ErrorIs(t, err, handlers.ErrCommitCanceled)
+}
+
+func TestShowCommitUICommitFailed(t *testing.T) {
+	form := &MockForm{}
+
+	helper := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "", errors.New("pre-commit hook failed")
+		},
+	}
+
+	config := &settings.Config{CommitFormat: "$type: $summary"}
+	err := handlers.ShowCommitUI(helper, config, form)
+
+	assert.ErrorContains(t, err, "pre-commit hook failed")
+	assert.NotErrorIs(t, err, handlers.ErrCommitCanceled)
+}
+
+func TestShowCommitUISigned(t *testing.T) {
+	form := &MockForm{}
+
+	var executed string
+	helper := &MockGitHelper{
+		ShowConfirmFunc: func(msg string, defaultYes ...bool) bool {
+			assert.Contains(t, msg, "Signed: yes (openpgp, key KEY), Signed-off-by trailer")
+			return true
+		},
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = cmd
+			return "", nil
+		},
+	}
+
+	config := &settings.Config{
+		CommitFormat: "$type: $summary",
+		Signing:      settings.Signing{Sign: settings.SignAlways, Key: "KEY", SignOff: true},
+	}
+
+	assert.NoError(t, handlers.ShowCommitUI(helper, config, form))
+	assert.Equal(t, "git commit -m 'feat: Initial commit' -S'KEY' --signoff", executed)
+}
+
+func TestShowCommitUIWithClosingTrailer(t *testing.T) {
+	form := &MockForm{
+		GetValuesFunc: func() (string, string, string, string) {
+			return "1.0", "fix", "#123", "Fix crash"
+		},
+	}
+
+	var executed string
+	helper := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = cmd
+			return "", nil
+		},
+	}
+
+	config := &settings.Config{
+		CommitFormat: "$type($ref): $summary",
+		IssueTracker: settings.TrackerGitHub,
+		CloseIssues:  true,
+	}
+
+	assert.NoError(t, handlers.ShowCommitUI(helper, config, form))
+	assert.Equal(t, "git commit -m 'fix(#123): Fix crash\n\nCloses #123'", executed)
 }
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixup.go methods
+func TestPickCommit(t *testing.T) {
+	original := helpers.GetSelectPromptFunc()
+	defer helpers.SetSelectPromptFunc(original)
+
+	commits := []handlers.CommitInfo{
+		{Hash: "aaaa1111", ShortHash: "aaaa", Message: "first"},
+		{Hash: "bbbb2222", ShortHash: "bbbb", Message: "second"},
+	}
+
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		assert.Len(t, options, 2)
+		assert.Equal(t, "bbbb second", options[1].Label)
+		return options[1].Value, true
+	})
+
+	commit, ok := handlers.PickCommit("Pick", commits)
+	assert.True(t, ok)
+	assert.Equal(t, "bbbb2222", commit.Hash)
+
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		return "", false
+	})
+
+	_, ok = handlers.PickCommit("Pick", commits)
+	assert.False(t, ok)
+}
+
+func TestCreateFixupCommit(t *testing.T) {
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			return "", nil
+		},
+	}
+
+	assert.NoError(t, handlers.CreateFixupCommit(mock, handlers.FixupCommit, "abc", settings.Signing{}))
+	assert.NoError(t, handlers.CreateFixupCommit(mock, handlers.SquashCommit, "abc", settings.Signing{SignOff: true}))
+	assert.Error(t, handlers.CreateFixupCommit(mock, "other", "abc", settings.Signing{}))
+	assert.Equal(t, []string{"git commit --no-edit --fixup=abc", "git commit --no-edit --squash=abc --signoff"}, executed)
+}
+
+func TestAutosquashBase(t *testing.T) {
+	target := handlers.CommitInfo{Hash: "abc"}
+
+	unpushed := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == commands.GitUpstream {
+				return "origin/feature\n", nil
+			}
+			return "", errors.New("exit status 1")
+		},
+	}
+	base, isPushed := handlers.AutosquashBase(unpushed, target)
+	assert.Equal(t, "origin/feature", base)
+	assert.False(t, isPushed)
+
+	pushed := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "origin/feature\n", nil
+		},
+	}
+	base, isPushed = handlers.AutosquashBase(pushed, target)
+	assert.Equal(t, "abc~1", base)
+	assert.True(t, isPushed)
+
+	noUpstream := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == commands.GitUpstream {
+				return "", errors.New("no upstream")
+			}
+			return "abc\n", nil
+		},
+	}
+	base, isPushed = handlers.AutosquashBase(noUpstream, target)
+	assert.Equal(t, "abc~1", base)
+	assert.False(t, isPushed)
+
+	root := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "", errors.New("exit status 1")
+		},
+	}
+	base, isPushed = handlers.AutosquashBase(root, target)
+	assert.Equal(t, "--root", base)
+	assert.False(t, isPushed)
+}
+
+func TestAutosquashRebase(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git -c sequence.editor=: -c core.editor=: rebase -i --autosquash --autostash origin/feature", cmd)
+			return "", nil
+		},
+	}
+
+	assert.NoError(t, handlers.AutosquashRebase(mock, "origin/feature"))
+}
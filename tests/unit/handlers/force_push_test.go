@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// force_push.go methods
+func TestForcePushWithLease(t *testing.T) {
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			switch {
+			case cmd == "git rev-parse --verify origin/feature":
+				return "abc123\n", nil
+			case strings.HasPrefix(cmd, "git log"):
+				assert.Contains(t, cmd, "HEAD..origin/feature")
+				return mockLogOutput, nil
+			}
+			return "", nil
+		},
+		ShowConfirmFunc: func(message string, defaultYes ...bool) bool {
+			assert.Contains(t, message, "overwrites these commits:\n-> aaaa [feat][SS-1] Add login\n-> bbbb Merge branch 'main'")
+			assert.False(t, defaultYes[0])
+			return true
+		},
+	}
+
+	config := &settings.Config{CommitFormat: "[$version][$type][$jira]: $summary", ProtectedBranches: []string{"main"}}
+	require.NoError(t, handlers.ForcePushWithLease(mock, config, "origin", "feature", "feature"))
+	assert.Equal(t, "git fetch origin", executed[0])
+	assert.Equal(t, "git push --force-with-lease=feature:abc123 origin feature:feature", executed[len(executed)-1])
+}
+
+func TestForcePushWithLeaseCanceled(t *testing.T) {
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			return "", nil
+		},
+		ShowConfirmFunc: func(message string, defaultYes ...bool) bool {
+			assert.Contains(t, message, "does not overwrite any commits")
+			return false
+		},
+	}
+
+	err := handlers.ForcePushWithLease(mock, &settings.Config{}, "origin", "feature", "feature")
+	assert.ErrorIs(t, err, handlers.ErrForcePushCanceled)
+	for _, cmd := range executed {
+		assert.NotContains(t, cmd, "--force-with-lease")
+	}
+}
+
+func TestForcePushWithLeaseRefusesProtectedBranches(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			t.Errorf("unexpected command %q", cmd)
+			return "", nil
+		},
+	}
+
+	config := &settings.Config{ProtectedBranches: []string{"main", "release/*"}}
+	assert.ErrorIs(t, handlers.ForcePushWithLease(mock, config, "origin", "main", "main"), handlers.ErrProtectedBranch)
+	assert.ErrorIs(t, handlers.ForcePushWithLease(mock, config, "origin", "hotfix", "release/1.0"), handlers.ErrProtectedBranch)
+}
+
+func TestRecoverRejectedPushOffersForcePush(t *testing.T) {
+	shown := mockSelectPrompt(t, "cancel", "cancel", "cancel")
+
+	diverged := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git rev-list --left-right --count HEAD...origin/feature" {
+				return "1\t2\n", nil
+			}
+			return "", nil
+		},
+	}
+	_, err := handlers.RecoverRejectedPush(diverged, &settings.Config{}, io.Discard, "origin", "feature", "feature")
+	assert.ErrorIs(t, err, handlers.ErrRecoveryAborted)
+
+	config := &settings.Config{ProtectedBranches: []string{"main"}}
+	_, err = handlers.RecoverRejectedPush(&MockGitHelper{}, config, io.Discard, "origin", "main", "main")
+	assert.ErrorIs(t, err, handlers.ErrRecoveryAborted)
+
+	behind := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git rev-list --left-right --count HEAD...origin/feature" {
+				return "0\t2\n", nil
+			}
+			return "", nil
+		},
+	}
+	_, err = handlers.RecoverRejectedPush(behind, &settings.Config{}, io.Discard, "origin", "feature", "feature")
+	assert.ErrorIs(t, err, handlers.ErrRecoveryAborted)
+
+	values := func(index int) []string {
+		var result []string
+		for _, option := range (*shown)[index] {
+			result = append(result, option.Value)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"rebase", "merge", "force", "cancel"}, values(0))
+	assert.Equal(t, []string{"rebase", "merge", "cancel"}, values(1))
+	assert.Equal(t, []string{"rebase", "merge", "cancel"}, values(2))
+}
+
+func TestUpstreamDiverged(t *testing.T) {
+	assert.True(t, (&handlers.Upstream{Ahead: 1, Behind: 1}).Diverged())
+	assert.False(t, (&handlers.Upstream{Ahead: 1}).Diverged())
+}
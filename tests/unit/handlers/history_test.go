@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/commands"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// history.go methods
+func newGitDirMock(gitDir string) *MockGitHelper {
+	return &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == commands.GitDir {
+				return gitDir + "\n", nil
+			}
+			return "", nil
+		},
+	}
+}
+
+func TestFormHistorySaveAndLoad(t *testing.T) {
+	gitDir := t.TempDir()
+	config := &settings.Config{DefaultVersion: "1.x", DefaultJiraReference: "SS-1"}
+
+	history := handlers.LoadFormHistory(newGitDirMock(gitDir), config)
+	assert.Empty(t, history.Entries)
+
+	version, jira := history.Defaults(config)
+	assert.Equal(t, "1.x", version)
+	assert.Equal(t, "SS-1", jira)
+
+	history.Record(handlers.HistoryEntry{Version: "2.0", CommitType: "feat", Jira: "SS-10", Summary: "first"})
+	history.Record(handlers.HistoryEntry{Version: "2.1", CommitType: "fix", Jira: "SS-10", Summary: "second"})
+	require.NoError(t, history.Save())
+
+	_, err := os.Stat(filepath.Join(gitDir, "git-commit-ui", "history.json"))
+	require.NoError(t, err)
+
+	loaded := handlers.LoadFormHistory(newGitDirMock(gitDir), config)
+	require.Len(t, loaded.Entries, 2)
+
+	version, jira = loaded.Defaults(config)
+	assert.Equal(t, "2.1", version)
+	assert.Equal(t, "SS-10", jira)
+	assert.Equal(t, []string{"2.1", "2.0"}, loaded.Versions())
+	assert.Equal(t, []string{"SS-10"}, loaded.References())
+}
+
+func TestFormHistoryMatchSummary(t *testing.T) {
+	history := &handlers.FormHistory{Entries: []handlers.HistoryEntry{
+		{Summary: "Update the changelog"},
+		{Summary: "Fix login redirect"},
+		{Summary: "Add login form"},
+	}}
+
+	summaries := func(entries []handlers.HistoryEntry) []string {
+		var values []string
+		for _, entry := range entries {
+			values = append(values, entry.Summary)
+		}
+		return values
+	}
+
+	assert.Equal(t, []string{"Update the changelog", "Fix login redirect", "Add login form"}, summaries(history.MatchSummary("")))
+	assert.Equal(t, []string{"Add login form"}, summaries(history.MatchSummary("lgn f")))
+	assert.Equal(t, []string{"Fix login redirect", "Add login form"}, summaries(history.MatchSummary("login")))
+	assert.Equal(t, []string{"Update the changelog"}, summaries(history.MatchSummary("CHANGE")))
+	assert.Empty(t, history.MatchSummary("xyz"))
+}
+
+func TestFormHistoryRecordDeduplicatesAndTrims(t *testing.T) {
+	config := &settings.Config{HistorySize: 2}
+	history := handlers.LoadFormHistory(newGitDirMock(t.TempDir()), config)
+
+	first := handlers.HistoryEntry{Version: "1", Summary: "a"}
+	history.Record(first)
+	history.Record(handlers.HistoryEntry{Version: "2", Summary: "b"})
+	history.Record(first)
+	history.Record(handlers.HistoryEntry{Version: "3", Summary: "c"})
+
+	assert.Equal(t, []string{"3", "1"}, history.Versions())
+}
+
+func TestFormHistoryDisabledOrOutsideRepository(t *testing.T) {
+	gitDir := t.TempDir()
+
+	disabled := handlers.LoadFormHistory(newGitDirMock(gitDir), &settings.Config{HistorySize: -1})
+	disabled.Record(handlers.HistoryEntry{Version: "1"})
+	require.NoError(t, disabled.Save())
+
+	_, err := os.Stat(filepath.Join(gitDir, "git-commit-ui"))
+	assert.True(t, os.IsNotExist(err))
+
+	missing := handlers.LoadFormHistory(newGitDirMock(filepath.Join(gitDir, "missing")), &settings.Config{})
+	missing.Record(handlers.HistoryEntry{Version: "1"})
+	require.NoError(t, missing.Save())
+
+	_, err = os.Stat(filepath.Join(gitDir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,61 @@
+package handlers_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hooks.go methods
+func mockHooksDir(t *testing.T) (*MockGitHelper, string) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	return &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git rev-parse --git-path hooks", cmd)
+			return dir + "\n", nil
+		},
+	}, dir
+}
+
+func TestCommitMsgHook(t *testing.T) {
+	hook := handlers.CommitMsgHook("/opt/it's/git-commit-ui")
+	assert.Contains(t, hook, "#!/bin/sh\n")
+	assert.Contains(t, hook, `exec '/opt/it'\''s/git-commit-ui' lint -message-file "$1"`)
+}
+
+func TestInstallCommitMsgHook(t *testing.T) {
+	mock, dir := mockHooksDir(t)
+
+	hook, err := handlers.InstallCommitMsgHook(mock, "/usr/bin/git-commit-ui", false)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "commit-msg"), hook)
+
+	info, err := os.Stat(hook)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "the hook must be executable")
+
+	// A hook written by this tool is replaced without force.
+	_, err = handlers.InstallCommitMsgHook(mock, "/usr/local/bin/git-commit-ui", false)
+	require.NoError(t, err)
+	data, _ := os.ReadFile(hook)
+	assert.Contains(t, string(data), "/usr/local/bin/git-commit-ui")
+}
+
+func TestInstallCommitMsgHookKeepsOtherHooks(t *testing.T) {
+	mock, dir := mockHooksDir(t)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	hook := filepath.Join(dir, "commit-msg")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\nexit 0\n"), 0755))
+
+	_, err := handlers.InstallCommitMsgHook(mock, "/usr/bin/git-commit-ui", false)
+	assert.ErrorIs(t, err, handlers.ErrHookExists)
+
+	_, err = handlers.InstallCommitMsgHook(mock, "/usr/bin/git-commit-ui", true)
+	require.NoError(t, err)
+	data, _ := os.ReadFile(hook)
+	assert.Contains(t, string(data), "lint -message-file")
+}
@@ -20,8 +20,7 @@ func TestCheckForGitInitialiseAlreadyInitialised(t *testing.T) {
 		},
 	}
 
-	exit := handlers.CheckForGitInitialise(mock)
-	assert.False(t, exit)
+	assert.NoError(t, handlers.CheckForGitInitialise(mock))
 }
 
 func TestCheckForGitInitialiseUserDeclinesInit(t *testing.T) {
@@ -34,8 +33,7 @@ func TestCheckForGitInitialiseUserDeclinesInit(t *testing.T) {
 		},
 	}
 
-	exit := handlers.CheckForGitInitialise(mock)
-	assert.True(t, exit)
+	assert.ErrorIs(t, handlers.CheckForGitInitialise(mock), handlers.ErrNotGitRepository)
 }
 
 func TestCheckForGitInitialiseUserAcceptsInit(t *testing.T) {
@@ -56,8 +54,7 @@ func TestCheckForGitInitialiseUserAcceptsInit(t *testing.T) {
 		},
 	}
 
-	exit := handlers.CheckForGitInitialise(mock)
-	assert.False(t, exit)
+	assert.NoError(t, handlers.CheckForGitInitialise(mock))
 	assert.Contains(t, calls, commands.GitInit)
 }
 
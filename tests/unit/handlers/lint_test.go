@@ -0,0 +1,57 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lint.go methods
+func newLintConfig() *settings.Config {
+	return &settings.Config{
+		CommitFormat: "[$version][$type][$jira]: $summary",
+		CommitTypes:  []string{"feat", "fix"},
+	}
+}
+
+func TestLintMessage(t *testing.T) {
+	config := newLintConfig()
+
+	tests := []struct {
+		name     string
+		message  string
+		problems []string
+	}{
+		{"conforming", "[1.x][feat][SS-1]: Add login\n\nDetails", nil},
+		{"comments ignored", "[1.x][fix][]: Fix crash\n# Please enter the commit message", nil},
+		{"merge", "Merge branch 'main' into feat", nil},
+		{"fixup", "fixup! [1.x][feat][SS-1]: Add login", nil},
+		{"not matching", "Add login", []string{`the message does not match the commit format "[$version][$type][$jira]: $summary"`}},
+		{"unknown type", "[1.x][feature][SS-1]: Add login", []string{`the type "feature" is not one of: feat, fix`}},
+		{"empty", "# only a comment\n", []string{"the message is empty"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, handlers.LintMessage(config, tt.message))
+		})
+	}
+}
+
+func TestLintCommits(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Contains(t, cmd, "-n 50 @{upstream}..HEAD")
+			return mockReportLog, nil
+		},
+	}
+
+	results, total, err := handlers.LintCommits(mock, newLintConfig(), "@{upstream}..HEAD", 50)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, "cccc", results[0].Commit.ShortHash)
+}
@@ -0,0 +1,115 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// log_filter.go methods
+func filterTestCommits(t *testing.T) []handlers.CommitInfo {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return mockLogOutput, nil
+		},
+	}
+
+	config := &settings.Config{CommitFormat: "[$version][$type][$jira]: $summary"}
+	commits, err := handlers.GetCommits(mock, config, "", 10)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	return commits
+}
+
+func TestLogFilterMatchesFields(t *testing.T) {
+	commits := filterTestCommits(t)
+
+	tests := []struct {
+		name   string
+		filter handlers.LogFilter
+		want   []string
+	}{
+		{"empty", handlers.LogFilter{}, []string{"aaaa", "bbbb"}},
+		{"type", handlers.LogFilter{Type: "FEAT"}, []string{"aaaa"}},
+		{"reference", handlers.LogFilter{Reference: "ss-1"}, []string{"aaaa"}},
+		{"version", handlers.LogFilter{Version: "2.x"}, nil},
+		{"author name", handlers.LogFilter{Author: "john"}, []string{"bbbb"}},
+		{"author email", handlers.LogFilter{Author: "jane@"}, []string{"aaaa"}},
+		{"text in body", handlers.LogFilter{Text: "details"}, []string{"aaaa"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, commit := range handlers.FilterCommits(commits, tt.filter) {
+				got = append(got, commit.ShortHash)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLogFilterMatchesDateRange(t *testing.T) {
+	commits := filterTestCommits(t)
+
+	since, err := handlers.ParseFilterDate("2025-01-02")
+	require.NoError(t, err)
+	filtered := handlers.FilterCommits(commits, handlers.LogFilter{Since: since.Add(-24 * time.Hour)})
+	assert.Len(t, filtered, 2)
+
+	// Until includes the whole day.
+	until := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filtered = handlers.FilterCommits(commits, handlers.LogFilter{Until: until})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "bbbb", filtered[0].ShortHash)
+
+	filtered = handlers.FilterCommits(commits, handlers.LogFilter{Since: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)})
+	assert.Empty(t, filtered)
+}
+
+func TestLogFilterDescribe(t *testing.T) {
+	assert.Equal(t, "none", handlers.LogFilter{}.Describe())
+	assert.True(t, handlers.LogFilter{}.IsEmpty())
+
+	since, _ := handlers.ParseFilterDate("2025-01-01")
+	filter := handlers.LogFilter{Type: "feat", Author: "jane", Since: since, Text: "login"}
+	assert.False(t, filter.IsEmpty())
+	assert.Equal(t, `type=feat, author=jane, since=2025-01-01, text="login"`, filter.Describe())
+}
+
+func TestParseFilterDate(t *testing.T) {
+	date, err := handlers.ParseFilterDate("")
+	require.NoError(t, err)
+	assert.True(t, date.IsZero())
+
+	date, err = handlers.ParseFilterDate(" 2025-03-04 ")
+	require.NoError(t, err)
+	assert.Equal(t, time.March, date.Month())
+
+	_, err = handlers.ParseFilterDate("04/03/2025")
+	assert.Error(t, err)
+}
+
+func TestCommitDetails(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git show --stat --patch --format=fuller aaaa1111", cmd)
+			return "diff --git a/x b/x", nil
+		},
+	}
+
+	details, err := handlers.CommitDetails(mock, "aaaa1111")
+	require.NoError(t, err)
+	assert.Equal(t, "diff --git a/x b/x", details)
+
+	mock.ExecuteCommandFunc = func(cmd string) (string, error) {
+		return "", errors.New("unknown revision")
+	}
+	_, err = handlers.CommitDetails(mock, "nope")
+	assert.Error(t, err)
+}
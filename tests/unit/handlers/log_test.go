@@ -0,0 +1,53 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// log.go methods
+const mockLogOutput = "aaaa1111\x1faaaa\x1fJane Doe\x1fjane@example.com\x1f2025-01-02T10:00:00+00:00\x1f[1.x][feat][SS-1]: Add login\n\nDetails\x1e\n" +
+	"bbbb2222\x1fbbbb\x1fJohn Roe\x1fjohn@example.com\x1f2025-01-01T09:00:00+00:00\x1fMerge branch 'main'\x1e\n"
+
+func TestGetCommits(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Contains(t, cmd, "git log --format=%H%x1f%h")
+			assert.Contains(t, cmd, "-n 10 v1.0..HEAD")
+			return mockLogOutput, nil
+		},
+	}
+
+	config := &settings.Config{CommitFormat: "[$version][$type][$jira]: $summary"}
+	commits, err := handlers.GetCommits(mock, config, "v1.0..HEAD", 10)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	assert.Equal(t, "aaaa1111", commits[0].Hash)
+	assert.Equal(t, "Jane Doe", commits[0].Author)
+	assert.Equal(t, "jane@example.com", commits[0].AuthorEmail)
+	assert.Equal(t, 2025, commits[0].Date.Year())
+	assert.True(t, commits[0].Conforming)
+	assert.Equal(t, "SS-1", commits[0].Parsed.Jira)
+	assert.Equal(t, "[1.x][feat][SS-1]: Add login", commits[0].Subject())
+	assert.Equal(t, "aaaa [feat][SS-1] Add login", commits[0].Label())
+
+	assert.False(t, commits[1].Conforming)
+	assert.Equal(t, "bbbb Merge branch 'main'", commits[1].Label())
+}
+
+func TestGetCommitsError(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "", errors.New("bad revision")
+		},
+	}
+
+	_, err := handlers.GetCommits(mock, &settings.Config{}, "nope", 10)
+	assert.Error(t, err)
+}
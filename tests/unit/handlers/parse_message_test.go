@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// parse_message.go methods
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		message  string
+		expected handlers.ParsedCommit
+		ok       bool
+	}{
+		{
+			"default format",
+			"[$version][$type][$jira]: $summary",
+			"[1.x][feat][SS-1234]: Add login page",
+			handlers.ParsedCommit{Version: "1.x", CommitType: "feat", Jira: "SS-1234", Summary: "Add login page"},
+			true,
+		},
+		{
+			"empty reference",
+			"[$version][$type][$jira]: $summary",
+			"[2.0][fix][]: Fix crash\n\nLonger description",
+			handlers.ParsedCommit{Version: "2.0", CommitType: "fix", Summary: "Fix crash\n\nLonger description"},
+			true,
+		},
+		{
+			"conventional format",
+			"$type($jira): $summary",
+			"docs(SS-9): Update README",
+			handlers.ParsedCommit{CommitType: "docs", Jira: "SS-9", Summary: "Update README"},
+			true,
+		},
+		{
+			"regex characters in format",
+			"$type.$version* $summary",
+			"feat.1.2* Something",
+			handlers.ParsedCommit{CommitType: "feat", Version: "1.2", Summary: "Something"},
+			true,
+		},
+		{
+			"not matching",
+			"[$version][$type][$jira]: $summary",
+			"Merge branch 'main'",
+			handlers.ParsedCommit{},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, ok := handlers.ParseCommitMessage(&settings.Config{CommitFormat: tt.format}, tt.message)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// protected_branch.go methods
+func TestIsProtectedBranch(t *testing.T) {
+	config := &settings.Config{ProtectedBranches: []string{"main", "release/*"}}
+
+	assert.True(t, handlers.IsProtectedBranch(config, "main"))
+	assert.True(t, handlers.IsProtectedBranch(config, "release/1.2"))
+	assert.False(t, handlers.IsProtectedBranch(config, "feat/main"))
+	assert.False(t, handlers.IsProtectedBranch(&settings.Config{}, "main"))
+}
+
+func TestFeatureBranchName(t *testing.T) {
+	config := &settings.Config{}
+
+	assert.Equal(t, "feat/SS-12-add-login-with-remember-me", handlers.FeatureBranchName(config, "1.x", "feat", "SS-12", "Add login, with 'remember me'!"))
+	assert.Equal(t, "feat/add-login", handlers.FeatureBranchName(config, "1.x", "feat", "", "Add login"))
+	assert.Equal(t, "fix", handlers.FeatureBranchName(config, "1.x", "fix", "", ""))
+
+	config.BranchTemplate = "$version/$type/$ref"
+	assert.Equal(t, "1.x/feat/group-project#12", handlers.FeatureBranchName(config, "1.x", "feat", "group project#12", "Add login"))
+}
+
+func TestGuardProtectedBranchCreatesBranch(t *testing.T) {
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			if cmd == "git rev-parse --abbrev-ref HEAD" {
+				return "main\n", nil
+			}
+			return "", nil
+		},
+		ShowConfirmFunc: func(message string, defaultYes ...bool) bool {
+			assert.Contains(t, message, "'main' is a protected branch")
+			assert.Contains(t, message, "'feat/SS-1-add-login'")
+			return true
+		},
+	}
+
+	config := &settings.Config{ProtectedBranches: []string{"main"}}
+	assert.NoError(t, handlers.GuardProtectedBranch(mock, config, "1.x", "feat", "SS-1", "Add login"))
+	assert.Equal(t, []string{"git rev-parse --abbrev-ref HEAD", "git switch -c feat/SS-1-add-login"}, executed)
+}
+
+func TestGuardProtectedBranchBlocks(t *testing.T) {
+	config := &settings.Config{ProtectedBranches: []string{"main"}}
+
+	declined := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) { return "main", nil },
+		ShowConfirmFunc:    func(message string, defaultYes ...bool) bool { return false },
+	}
+	assert.ErrorIs(t, handlers.GuardProtectedBranch(declined, config, "1.x", "feat", "SS-1", "Add login"), handlers.ErrCommitCanceled)
+
+	branchExists := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git rev-parse --abbrev-ref HEAD" {
+				return "main", nil
+			}
+			return "fatal: a branch named 'feat/SS-1-add-login' already exists", errors.New("exit status 128")
+		},
+	}
+	assert.ErrorContains(t, handlers.GuardProtectedBranch(branchExists, config, "1.x", "feat", "SS-1", "Add login"), "failed to create branch")
+
+	unprotected := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) { return "feat/login", nil },
+	}
+	assert.NoError(t, handlers.GuardProtectedBranch(unprotected, config, "1.x", "feat", "SS-1", "Add login"))
+}
@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/forges"
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pull_request.go methods
+type mockForge struct {
+	options forges.PullRequestOptions
+}
+
+func (f *mockForge) Name() string { return "Mock" }
+
+func (f *mockForge) DefaultBranch(ctx context.Context) (string, error) { return "main", nil }
+
+func (f *mockForge) FindOrCreatePullRequest(ctx context.Context, options forges.PullRequestOptions) (*forges.PullRequest, error) {
+	f.options = options
+	return &forges.PullRequest{Number: 1, URL: "https://example.com/pr/1"}, nil
+}
+
+func TestPullRequestText(t *testing.T) {
+	title, body := handlers.PullRequestText(nil)
+	assert.Empty(t, title)
+	assert.Empty(t, body)
+
+	title, body = handlers.PullRequestText([]handlers.CommitInfo{{Message: "Add login\n\nWith remember me"}})
+	assert.Equal(t, "Add login", title)
+	assert.Equal(t, "With remember me", body)
+
+	title, body = handlers.PullRequestText([]handlers.CommitInfo{{Message: "Fix typo"}, {Message: "Add login\n\nDetails"}})
+	assert.Equal(t, "Add login", title)
+	assert.Equal(t, "- Add login\n- Fix typo", body)
+}
+
+func TestOpenPullRequest(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Contains(t, cmd, "upstream/main..HEAD")
+			return mockLogOutput, nil
+		},
+	}
+
+	config := &settings.Config{
+		CommitFormat: "[$version][$type][$jira]: $summary",
+		PullRequest:  settings.PullRequest{Reviewers: []string{"jane"}, Labels: []string{"ui"}, Draft: true},
+	}
+
+	forge := &mockForge{}
+	pullRequest, err := handlers.OpenPullRequest(context.Background(), mock, config, forge, "upstream", "feature")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/pr/1", pullRequest.URL)
+	assert.Equal(t, forges.PullRequestOptions{
+		Head:      "feature",
+		Base:      "main",
+		Title:     "Merge branch 'main'",
+		Body:      "- Merge branch 'main'\n- [1.x][feat][SS-1]: Add login",
+		Reviewers: []string{"jane"},
+		Labels:    []string{"ui"},
+		Draft:     true,
+	}, forge.options)
+}
+
+func TestOpenPullRequestFromBaseBranch(t *testing.T) {
+	config := &settings.Config{PullRequest: settings.PullRequest{Base: "main"}}
+
+	_, err := handlers.OpenPullRequest(context.Background(), &MockGitHelper{}, config, &mockForge{}, "origin", "main")
+	assert.Error(t, err)
+}
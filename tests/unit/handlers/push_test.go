@@ -3,10 +3,13 @@ package handlers_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // push.go methods
@@ -20,10 +23,10 @@ func TestPushSuccess(t *testing.T) {
 		},
 	}
 
-	handlers.PushToOrigin(mock, "main")
+	handlers.PushToRemote(mock, "origin", "main")
 }
 
-func TestPushToOriginFailure(t *testing.T) {
+func TestPushToRemoteFailure(t *testing.T) {
 	mock := &MockGitHelper{
 		ExecuteCommandFunc: func(cmd string) (string, error) {
 			expected := "git push -u origin main"
@@ -32,5 +35,116 @@ func TestPushToOriginFailure(t *testing.T) {
 		},
 	}
 
-	handlers.PushToOrigin(mock, "main")
+	handlers.PushToRemote(mock, "origin", "main")
+}
+
+const mockRemotesOutput = "origin\tgit@github.com:me/repo.git (fetch)\norigin\tgit@github.com:me/repo.git (push)\n" +
+	"upstream\thttps://github.com/owner/repo.git (fetch)\nupstream\thttps://github.com/owner/repo.git (push)\n"
+
+func TestGetRemotes(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git remote -v", cmd)
+			return mockRemotesOutput, nil
+		},
+	}
+
+	remotes, err := handlers.GetRemotes(mock)
+	require.NoError(t, err)
+	assert.Equal(t, []handlers.Remote{
+		{Name: "origin", URL: "git@github.com:me/repo.git"},
+		{Name: "upstream", URL: "https://github.com/owner/repo.git"},
+	}, remotes)
+}
+
+func TestTrackingRemote(t *testing.T) {
+	remotes := []handlers.Remote{{Name: "fork"}, {Name: "origin"}, {Name: "upstream"}}
+
+	tracking := ""
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git config --get branch.main.remote", cmd)
+			if tracking == "" {
+				return "", errors.New("not set")
+			}
+			return tracking + "\n", nil
+		},
+	}
+
+	assert.Equal(t, "origin", handlers.TrackingRemote(mock, "main", remotes))
+
+	tracking = "upstream"
+	assert.Equal(t, "upstream", handlers.TrackingRemote(mock, "main", remotes))
+
+	tracking = "gone"
+	assert.Equal(t, "fork", handlers.TrackingRemote(mock, "main", remotes[:1]))
+	assert.Empty(t, handlers.TrackingRemote(mock, "main", nil))
+}
+
+func TestSelectPushRemotes(t *testing.T) {
+	original := helpers.GetMultiSelectPromptFunc()
+	defer helpers.SetMultiSelectPromptFunc(original)
+
+	helpers.SetMultiSelectPromptFunc(func(title string, options []helpers.SelectOption, selected []string) ([]string, bool) {
+		assert.Equal(t, []string{"upstream"}, selected)
+		assert.Equal(t, "origin (git@github.com:me/repo.git)", options[0].Label)
+		return []string{"upstream", "origin"}, true
+	})
+
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "upstream", nil
+		},
+	}
+
+	remotes := []handlers.Remote{{Name: "origin", URL: "git@github.com:me/repo.git"}, {Name: "upstream", URL: "https://github.com/owner/repo.git"}}
+	selected, ok := handlers.SelectPushRemotes(mock, "main", remotes)
+	assert.True(t, ok)
+	assert.Equal(t, remotes, selected)
+}
+
+func TestSelectPushRemotesSingleRemote(t *testing.T) {
+	mock := &MockGitHelper{
+		ShowConfirmFunc: func(title string, defaultValue ...bool) bool {
+			assert.Equal(t, "Do you want to push the current branch 'main' to origin?", title)
+			return false
+		},
+	}
+
+	_, ok := handlers.SelectPushRemotes(mock, "main", []handlers.Remote{{Name: "origin"}})
+	assert.False(t, ok)
+}
+
+func TestPushToRemotes(t *testing.T) {
+	var commands []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			commands = append(commands, cmd)
+			if strings.Contains(cmd, "mirror") {
+				return "rejected", errors.New("push failed")
+			}
+			return "", nil
+		},
+	}
+
+	results := handlers.PushToRemotes(mock, []handlers.Remote{{Name: "origin", URL: "a"}, {Name: "mirror", URL: "b"}}, "main", nil)
+	assert.Equal(t, []string{"git push -u origin main", "git push -u mirror main"}, commands)
+	require.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.Equal(t, "✓ origin (a)\n✗ mirror (b): push failed", handlers.PushSummary(results))
+}
+
+func TestPushToRemotesWithUpstream(t *testing.T) {
+	var commands []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			commands = append(commands, cmd)
+			return "", nil
+		},
+	}
+
+	upstream := &handlers.Upstream{Remote: "upstream", Branch: "feature/login"}
+	handlers.PushToRemotes(mock, []handlers.Remote{{Name: "upstream"}, {Name: "mirror"}}, "login", upstream)
+	assert.Equal(t, []string{"git push upstream login:feature/login", "git push mirror login:login"}, commands)
 }
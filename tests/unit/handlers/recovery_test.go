@@ -0,0 +1,161 @@
+package handlers_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recovery.go methods
+const mockRejectedPush = "failed to execute command: exit status 1\nOutput:\n" +
+	" ! [rejected]        main -> main (fetch first)\nerror: failed to push some refs\n"
+
+// mockSelectPrompt replaces the select prompt with one that answers with the
+// given values in turn, recording the options it was shown.
+func mockSelectPrompt(t *testing.T, answers ...string) *[][]helpers.SelectOption {
+	t.Helper()
+
+	original := helpers.GetSelectPromptFunc()
+	t.Cleanup(func() { helpers.SetSelectPromptFunc(original) })
+
+	var shown [][]helpers.SelectOption
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		shown = append(shown, options)
+		require.NotEmpty(t, answers, "unexpected prompt %q", title)
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, true
+	})
+
+	return &shown
+}
+
+func TestIsRejectedPush(t *testing.T) {
+	assert.True(t, handlers.IsRejectedPush(errors.New(mockRejectedPush)))
+	assert.True(t, handlers.IsRejectedPush(errors.New("! [rejected] main -> main (non-fast-forward)")))
+	assert.False(t, handlers.IsRejectedPush(errors.New("Permission denied (publickey)")))
+	assert.False(t, handlers.IsRejectedPush(nil))
+}
+
+func TestRecoverRejectedPushRebase(t *testing.T) {
+	shown := mockSelectPrompt(t, settings.PullRebase)
+
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			return "", nil
+		},
+	}
+
+	_, err := handlers.RecoverRejectedPush(mock, &settings.Config{}, io.Discard, "origin", "main", "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git fetch origin", "git rev-list --left-right --count HEAD...origin/main", "git pull --rebase origin main"}, executed)
+	assert.Equal(t, settings.PullRebase, (*shown)[0][0].Value)
+}
+
+func TestRecoverRejectedPushPrefersConfiguredStrategy(t *testing.T) {
+	shown := mockSelectPrompt(t, "cancel")
+
+	config := &settings.Config{Push: settings.Push{PullStrategy: settings.PullMerge}}
+	_, err := handlers.RecoverRejectedPush(&MockGitHelper{}, config, io.Discard, "origin", "main", "main")
+	assert.ErrorIs(t, err, handlers.ErrRecoveryAborted)
+	assert.Equal(t, settings.PullMerge, (*shown)[0][0].Value)
+}
+
+func TestRecoverRejectedPushWithConflicts(t *testing.T) {
+	root := t.TempDir()
+	conflicted := filepath.Join(root, "main.go")
+	require.NoError(t, os.WriteFile(conflicted, []byte("<<<<<<< HEAD\na\n=======\nb\n>>>>>>> theirs\n"), 0644))
+
+	// The first continue finds the markers still in the file, the user then
+	// resolves the conflict and continues again.
+	original := helpers.GetSelectPromptFunc()
+	t.Cleanup(func() { helpers.SetSelectPromptFunc(original) })
+
+	conflictPrompts := 0
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		if !strings.HasPrefix(title, "Conflicts") {
+			return settings.PullMerge, true
+		}
+
+		conflictPrompts++
+		if conflictPrompts == 2 {
+			require.NoError(t, os.WriteFile(conflicted, []byte("a\nb\n"), 0644))
+		}
+		return "continue", true
+	})
+
+	resolved := false
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			switch cmd {
+			case "git pull --no-rebase --no-edit origin main":
+				return "CONFLICT (content)", errors.New("exit status 1")
+			case "git diff --name-only --diff-filter=U":
+				if resolved {
+					return "", nil
+				}
+				return "main.go\n", nil
+			case "git rev-parse --show-toplevel":
+				return root + "\n", nil
+			case "git add -- 'main.go'":
+				resolved = true
+			}
+			return "", nil
+		},
+	}
+
+	_, err := handlers.RecoverRejectedPush(mock, &settings.Config{}, io.Discard, "origin", "main", "main")
+	require.NoError(t, err)
+	assert.Equal(t, 2, conflictPrompts)
+	assert.Contains(t, executed, "git add -- 'main.go'")
+	assert.Contains(t, executed, "git commit --no-edit")
+}
+
+func TestResolveConflictsAbort(t *testing.T) {
+	mockSelectPrompt(t, "abort")
+
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			return "", nil
+		},
+	}
+
+	err := handlers.ResolveConflicts(mock, io.Discard, settings.PullRebase, []string{"main.go"})
+	assert.ErrorIs(t, err, handlers.ErrRecoveryAborted)
+	assert.Equal(t, []string{"git rebase --abort"}, executed)
+}
+
+func TestResolveConflictsContinueFails(t *testing.T) {
+	mockSelectPrompt(t, "continue")
+
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			if cmd == "git -c core.editor=true rebase --continue" {
+				return "", errors.New("exit status 1")
+			}
+			return "", nil
+		},
+	}
+
+	err := handlers.ResolveConflicts(mock, io.Discard, settings.PullRebase, []string{"it's.go"})
+	assert.ErrorContains(t, err, "failed to continue the rebase")
+	assert.Contains(t, executed, `git add -- 'it'"'"'s.go'`)
+	assert.Equal(t, "git rebase --abort", executed[len(executed)-1])
+}
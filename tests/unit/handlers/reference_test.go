@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+	"github.com/stretchr/testify/assert"
+)
+
+// reference.go methods
+type mockTracker struct {
+	issues   map[string]trackers.Issue
+	offline  bool
+	requests int
+}
+
+func (m *mockTracker) Name() string {
+	return "Mock"
+}
+
+func (m *mockTracker) ReferenceHint() string {
+	return "key like SS-1"
+}
+
+func (m *mockTracker) ValidReference(reference string) bool {
+	return strings.HasPrefix(reference, "SS-")
+}
+
+func (m *mockTracker) IssueURL(reference string) string {
+	return ""
+}
+
+func (m *mockTracker) GetIssue(ctx context.Context, key string) (*trackers.Issue, error) {
+	m.requests++
+	if m.offline {
+		return nil, trackers.ErrUnavailable
+	}
+	if issue, ok := m.issues[key]; ok {
+		return &issue, nil
+	}
+	return nil, trackers.ErrIssueNotFound
+}
+
+func (m *mockTracker) SearchIssues(ctx context.Context, text string) ([]trackers.Issue, error) {
+	m.requests++
+	if m.offline {
+		return nil, trackers.ErrUnavailable
+	}
+	var issues []trackers.Issue
+	for _, issue := range m.issues {
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+func newMockTracker() *mockTracker {
+	return &mockTracker{issues: map[string]trackers.Issue{
+		"SS-1234": {Key: "SS-1234", Title: "Add login page", Status: "In Progress"},
+	}}
+}
+
+func TestNewReferenceLookupWithoutTracker(t *testing.T) {
+	assert.Nil(t, handlers.NewReferenceLookup(nil))
+}
+
+func TestReferenceLookupValidate(t *testing.T) {
+	tracker := newMockTracker()
+	lookup := handlers.NewReferenceLookup(tracker)
+
+	assert.NoError(t, lookup.Validate(""))
+	assert.NoError(t, lookup.Validate("SS-1234"))
+	assert.EqualError(t, lookup.Validate("SS-12345"), "issue SS-12345 was not found")
+	assert.EqualError(t, lookup.Validate("#12"), "#12 is not a valid reference, expected a key like SS-1")
+
+	// cached results do not repeat the request
+	assert.NoError(t, lookup.Validate("SS-1234"))
+	assert.Equal(t, 2, tracker.requests)
+}
+
+func TestReferenceLookupDescribe(t *testing.T) {
+	lookup := handlers.NewReferenceLookup(newMockTracker())
+
+	assert.Equal(t, "Add login page [In Progress]", lookup.Describe("SS-1234"))
+	assert.Equal(t, "Issue not found in Mock", lookup.Describe("SS-1"))
+	assert.Equal(t, "Expected a key like SS-1", lookup.Describe("#12"))
+	assert.Equal(t, []string{"SS-1234"}, lookup.Suggest("SS"))
+}
+
+func TestReferenceLookupOffline(t *testing.T) {
+	tracker := newMockTracker()
+	tracker.offline = true
+	lookup := handlers.NewReferenceLookup(tracker)
+
+	assert.NoError(t, lookup.Validate("SS-12345"))
+	assert.Contains(t, lookup.Describe("SS-12345"), "unavailable")
+	assert.Empty(t, lookup.Suggest("SS"))
+	assert.Equal(t, 1, tracker.requests)
+}
@@ -0,0 +1,164 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// release.go methods
+func newReleaseConfig() *settings.Config {
+	return &settings.Config{
+		CommitFormat: "[$version][$type][$jira]: $summary",
+		Release: settings.Release{
+			TagPrefix: "v",
+			Major:     []string{"breaking"},
+			Minor:     []string{"feat"},
+			Patch:     []string{"fix"},
+		},
+	}
+}
+
+// mockReleaseLog returns git log output for commits with the given messages.
+func mockReleaseLog(messages ...string) string {
+	var records []string
+	for _, message := range messages {
+		records = append(records, "aaaa\x1faaaa\x1fJane Doe\x1fjane@example.com\x1f2025-01-02T10:00:00+00:00\x1f"+message+"\x1e\n")
+	}
+	return strings.Join(records, "")
+}
+
+func TestParseVersion(t *testing.T) {
+	version, ok := handlers.ParseVersion("v1.2.3", "v")
+	assert.True(t, ok)
+	assert.Equal(t, handlers.Version{Major: 1, Minor: 2, Patch: 3}, version)
+
+	version, ok = handlers.ParseVersion("v2.0.0-rc.1", "v")
+	assert.True(t, ok)
+	assert.Equal(t, "2.0.0", version.String())
+
+	_, ok = handlers.ParseVersion("1.2.3", "v")
+	assert.False(t, ok)
+	_, ok = handlers.ParseVersion("v1.2", "v")
+	assert.False(t, ok)
+}
+
+func TestVersionBump(t *testing.T) {
+	version := handlers.Version{Major: 1, Minor: 2, Patch: 3}
+	assert.Equal(t, "2.0.0", version.Bump(handlers.BumpMajor).String())
+	assert.Equal(t, "1.3.0", version.Bump(handlers.BumpMinor).String())
+	assert.Equal(t, "1.2.4", version.Bump(handlers.BumpPatch).String())
+	assert.Equal(t, "1.2.3", version.Bump(handlers.BumpNone).String())
+}
+
+func TestNextRelease(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git describe --tags --abbrev=0 --match v*" {
+				return "v1.2.3\n", nil
+			}
+			assert.Contains(t, cmd, "v1.2.3..HEAD")
+			return mockReleaseLog("[1.x][fix][SS-2]: Fix crash", "[1.x][feat][SS-1]: Add login", "[1.x][docs][]: Update readme"), nil
+		},
+	}
+
+	config := newReleaseConfig()
+	release, err := handlers.NextRelease(mock, config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.3", release.PreviousTag)
+	assert.Equal(t, handlers.BumpMinor, release.Bump)
+	assert.Equal(t, "v1.3.0", release.Tag(config))
+	assert.Equal(t, "Release v1.3.0\n\nFeatures:\n- feat: Add login (SS-1)\n\nFixes and improvements:\n- fix: Fix crash (SS-2)", handlers.ReleaseMessage(config, release))
+}
+
+func TestNextReleaseFirstReleaseWithBreakingChange(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if strings.HasPrefix(cmd, "git describe") {
+				return "fatal: No names found", errors.New("exit status 128")
+			}
+			assert.NotContains(t, cmd, "..HEAD")
+			return mockReleaseLog("[1.x][fix][SS-3]: Drop v1 API\n\nBREAKING CHANGE: the v1 API is gone"), nil
+		},
+	}
+
+	release, err := handlers.NextRelease(mock, newReleaseConfig())
+	require.NoError(t, err)
+	assert.Empty(t, release.PreviousTag)
+	assert.Equal(t, handlers.BumpMajor, release.Bump)
+	assert.Equal(t, "1.0.0", release.Next.String())
+}
+
+func TestNextReleaseOlderConfig(t *testing.T) {
+	var config settings.Config
+	require.NoError(t, json.Unmarshal([]byte(`{"commit_format": "[$version][$type][$jira]: $summary"}`), &config))
+
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if cmd == "git describe --tags --abbrev=0 --match v*" {
+				return "v1.2.3\n", nil
+			}
+			assert.Contains(t, cmd, "v1.2.3..HEAD")
+			return mockReleaseLog("[1.x][perf][SS-2]: Speed up search"), nil
+		},
+	}
+
+	release, err := handlers.NextRelease(mock, &config)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", release.PreviousTag)
+	assert.Equal(t, handlers.BumpPatch, release.Bump)
+	assert.Equal(t, "v1.2.4", release.Tag(&config))
+}
+
+func TestReleaseTagPrefix(t *testing.T) {
+	assert.Equal(t, "v", handlers.ReleaseTagPrefix(&settings.Config{}))
+	assert.Equal(t, "release-", handlers.ReleaseTagPrefix(&settings.Config{Release: settings.Release{TagPrefix: "release-"}}))
+	assert.Equal(t, "", handlers.ReleaseTagPrefix(&settings.Config{Release: settings.Release{Minor: []string{"feat"}}}))
+}
+
+func TestCommitBump(t *testing.T) {
+	config := newReleaseConfig()
+
+	assert.Equal(t, handlers.BumpMajor, handlers.CommitBump(config, handlers.CommitInfo{Conforming: true, Parsed: handlers.ParsedCommit{CommitType: "feat!"}}))
+	assert.Equal(t, handlers.BumpMajor, handlers.CommitBump(config, handlers.CommitInfo{Conforming: true, Parsed: handlers.ParsedCommit{CommitType: "breaking"}}))
+	assert.Equal(t, handlers.BumpNone, handlers.CommitBump(config, handlers.CommitInfo{Message: "Merge branch 'main'"}))
+}
+
+func TestCommitBumpDefaultTypes(t *testing.T) {
+	config := &settings.Config{}
+
+	assert.Equal(t, handlers.BumpMinor, handlers.CommitBump(config, handlers.CommitInfo{Conforming: true, Parsed: handlers.ParsedCommit{CommitType: "feat"}}))
+	assert.Equal(t, handlers.BumpPatch, handlers.CommitBump(config, handlers.CommitInfo{Conforming: true, Parsed: handlers.ParsedCommit{CommitType: "fix"}}))
+
+	config.Release.Patch = []string{}
+	assert.Equal(t, handlers.BumpNone, handlers.CommitBump(config, handlers.CommitInfo{Conforming: true, Parsed: handlers.ParsedCommit{CommitType: "fix"}}))
+}
+
+func TestCreateReleaseTag(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, `git tag -a v1.3.0 -m 'Release v1.3.0: Don'"'"'t crash'`, cmd)
+			return "", nil
+		},
+	}
+
+	require.NoError(t, handlers.CreateReleaseTag(mock, "v1.3.0", "Release v1.3.0: Don't crash"))
+}
+
+func TestPushTag(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git push origin refs/tags/v1.3.0", cmd)
+			return "", nil
+		},
+	}
+
+	require.NoError(t, handlers.PushTag(mock, "origin", "v1.3.0"))
+}
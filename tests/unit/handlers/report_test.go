@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// report.go methods
+const mockReportLog = "aaaa1111\x1faaaa\x1fJane Doe\x1fjane@example.com\x1f2025-01-03T10:00:00+00:00\x1f[1.x][fix][SS-2]: Fix login crash\n\nRefs: SS-1\x1e\n" +
+	"bbbb2222\x1fbbbb\x1fJohn Roe\x1fjohn@example.com\x1f2025-01-02T10:00:00+00:00\x1f[1.x][feat][SS-1]: Add login\x1e\n" +
+	"cccc3333\x1fcccc\x1fJohn Roe\x1fjohn@example.com\x1f2025-01-01T10:00:00+00:00\x1fFixes the build\x1e\n"
+
+const mockReportFiles = "\x1eaaaa1111\n\nsrc/login.go\n\x1ebbbb2222\n\nsrc/login.go\nsrc/form.go\n\x1ecccc3333\n\nMakefile\n"
+
+func mockReportHelper(t *testing.T) *MockGitHelper {
+	return &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Contains(t, cmd, "v1.2.0..HEAD")
+			if strings.Contains(cmd, "--name-only") {
+				return mockReportFiles, nil
+			}
+			return mockReportLog, nil
+		},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	report, err := handlers.BuildReport(mockReportHelper(t), newReleaseConfig(), "v1.2.0", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.0", report.From)
+	assert.Equal(t, "HEAD", report.To)
+	require.Len(t, report.Groups, 3)
+
+	ss1 := report.Groups[0]
+	assert.Equal(t, "SS-1", ss1.Reference)
+	require.Len(t, ss1.Commits, 2)
+	assert.Equal(t, []string{"Jane Doe", "John Roe"}, ss1.Authors)
+	assert.Equal(t, []string{"src/form.go", "src/login.go"}, ss1.Files)
+	assert.Equal(t, []string{"1.x"}, ss1.Versions)
+
+	assert.Equal(t, "SS-2", report.Groups[1].Reference)
+	assert.Len(t, report.Groups[1].Commits, 1)
+
+	none := report.Groups[2]
+	assert.Equal(t, "", none.Reference)
+	require.Len(t, none.Commits, 1)
+	assert.Equal(t, []string{"Makefile"}, none.Commits[0].Files)
+}
+
+func TestCommitReferences(t *testing.T) {
+	commit := handlers.CommitInfo{
+		Message:    "[1.x][fix][SS-2]: Fix\n\nCloses #12\nRefs: SS-3, SS-2, owner/repo#4\nFixes the flaky test",
+		Conforming: true,
+		Parsed:     handlers.ParsedCommit{Jira: "SS-2"},
+	}
+	assert.Equal(t, []string{"SS-2", "#12", "SS-3", "owner/repo#4"}, handlers.CommitReferences(commit))
+
+	// The subject is never read as a trailer.
+	assert.Empty(t, handlers.CommitReferences(handlers.CommitInfo{Message: "Fixes SS-9"}))
+}
+
+func TestReportFormats(t *testing.T) {
+	report, err := handlers.BuildReport(mockReportHelper(t), newReleaseConfig(), "v1.2.0", "")
+	require.NoError(t, err)
+
+	markdown := report.Markdown()
+	assert.Contains(t, markdown, "# Commits by reference (v1.2.0..HEAD)")
+	assert.Contains(t, markdown, "## SS-1\n\n- Authors: Jane Doe, John Roe\n- Versions: 1.x\n- Files touched: 2")
+	assert.Contains(t, markdown, "| aaaa111 | 2025-01-03 | Jane Doe | [1.x][fix][SS-2]: Fix login crash |")
+	assert.Contains(t, markdown, "## No reference")
+
+	data, err := report.CSV()
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "reference,hash,date,author,type,version,subject,files", lines[0])
+	assert.Equal(t, "SS-1,bbbb2222,2025-01-02T10:00:00Z,John Roe,feat,1.x,[1.x][feat][SS-1]: Add login,src/login.go;src/form.go", lines[2])
+
+	encoded, err := report.JSON()
+	require.NoError(t, err)
+	var decoded handlers.Report
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Len(t, decoded.Groups, 3)
+}
@@ -0,0 +1,75 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// signing.go methods
+func newGitConfigMock(values map[string]string) *MockGitHelper {
+	return &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			for key, value := range values {
+				if cmd == "git config --get "+key {
+					return value + "\n", nil
+				}
+			}
+			return "", errors.New("exit status 1")
+		},
+	}
+}
+
+func TestResolveSigningAuto(t *testing.T) {
+	mock := newGitConfigMock(map[string]string{"commit.gpgsign": "true", "user.signingkey": "ABC123"})
+
+	signing := handlers.ResolveSigning(mock, &settings.Config{Signing: settings.Signing{Sign: settings.SignAuto}})
+	assert.Equal(t, settings.SignAlways, signing.Sign)
+	assert.Equal(t, "ABC123", signing.Key)
+
+	signing = handlers.ResolveSigning(newGitConfigMock(nil), &settings.Config{})
+	assert.Equal(t, settings.SignNever, signing.Sign)
+	assert.Empty(t, signing.Key)
+}
+
+func TestResolveSigningExplicit(t *testing.T) {
+	mock := newGitConfigMock(map[string]string{"commit.gpgsign": "true", "user.signingkey": "ABC123"})
+
+	signing := handlers.ResolveSigning(mock, &settings.Config{Signing: settings.Signing{Sign: settings.SignNever}})
+	assert.Equal(t, settings.SignNever, signing.Sign)
+
+	signing = handlers.ResolveSigning(mock, &settings.Config{Signing: settings.Signing{Sign: settings.SignAlways, Key: "XYZ"}})
+	assert.Equal(t, settings.SignAlways, signing.Sign)
+	assert.Equal(t, "XYZ", signing.Key)
+}
+
+func TestSigningFlags(t *testing.T) {
+	assert.Equal(t, " -S", handlers.SigningFlags(settings.Signing{Sign: settings.SignAlways}))
+	assert.Equal(t, " -S'~/.ssh/id_ed25519.pub' --signoff", handlers.SigningFlags(settings.Signing{Sign: settings.SignAlways, Key: "~/.ssh/id_ed25519.pub", SignOff: true}))
+	assert.Equal(t, " --no-gpg-sign", handlers.SigningFlags(settings.Signing{Sign: settings.SignNever}))
+	assert.Equal(t, "", handlers.SigningFlags(settings.Signing{}))
+}
+
+func TestDescribeSigning(t *testing.T) {
+	mock := newGitConfigMock(map[string]string{"gpg.format": "ssh"})
+
+	assert.Equal(t, "Signed: yes (ssh, key KEY), Signed-off-by trailer", handlers.DescribeSigning(mock, settings.Signing{Sign: settings.SignAlways, Key: "KEY", SignOff: true}))
+	assert.Equal(t, "Signed: yes (openpgp)", handlers.DescribeSigning(newGitConfigMock(nil), settings.Signing{Sign: settings.SignAlways}))
+	assert.Equal(t, "Signed: no", handlers.DescribeSigning(mock, settings.Signing{Sign: settings.SignNever}))
+	assert.Empty(t, handlers.DescribeSigning(mock, settings.Signing{}))
+}
+
+func TestDescribeCommitError(t *testing.T) {
+	err := handlers.DescribeCommitError(errors.New("error: gpg failed to sign the data\ngpg: skipped \"ABC\": No secret key"))
+	assert.ErrorContains(t, err, "signing key was not found")
+
+	err = handlers.DescribeCommitError(errors.New("error: Couldn't get agent socket?"))
+	assert.ErrorContains(t, err, "ssh agent is not running")
+
+	original := errors.New("nothing to commit")
+	assert.Equal(t, original, handlers.DescribeCommitError(original))
+	assert.NoError(t, handlers.DescribeCommitError(nil))
+}
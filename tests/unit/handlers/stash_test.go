@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stash.go methods
+func TestGetStashes(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git stash list --format=%gd%x1f%cr%x1f%gs", cmd)
+			return "stash@{0}\x1f5 minutes ago\x1fOn main: work in progress\nstash@{1}\x1f2 days ago\x1fWIP on main: aaaa Add login\n", nil
+		},
+	}
+
+	stashes, err := handlers.GetStashes(mock)
+	require.NoError(t, err)
+	require.Len(t, stashes, 2)
+	assert.Equal(t, "stash@{0}  5 minutes ago  On main: work in progress", stashes[0].Label())
+	assert.Equal(t, "stash@{1}", stashes[1].Ref)
+}
+
+func TestStashActions(t *testing.T) {
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			return "", nil
+		},
+	}
+
+	_, err := handlers.ShowStash(mock, "stash@{1}")
+	require.NoError(t, err)
+	require.NoError(t, handlers.ApplyStash(mock, "stash@{1}"))
+	require.NoError(t, handlers.PopStash(mock, "stash@{1}"))
+	require.NoError(t, handlers.DropStash(mock, "stash@{1}"))
+
+	assert.Equal(t, []string{
+		"git stash show --stat --patch stash@{1}",
+		"git stash apply stash@{1}",
+		"git stash pop stash@{1}",
+		"git stash drop stash@{1}",
+	}, executed)
+}
+
+func TestUnselectedChanges(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "M  staged.go\nMM partly.go\n M unstaged.go\n?? new.go\nA  added.go\n", nil
+		},
+	}
+
+	unselected, partial := handlers.UnselectedChanges(mock)
+	assert.Equal(t, []string{"unstaged.go", "new.go"}, unselected)
+	assert.Equal(t, []string{"partly.go"}, partial)
+}
+
+func TestStashAndRestoreUnselectedChanges(t *testing.T) {
+	var stashed string
+	var executed []string
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			executed = append(executed, cmd)
+			switch {
+			case strings.HasPrefix(cmd, "git stash push --include-untracked -m '"):
+				stashed = strings.TrimSuffix(strings.TrimPrefix(cmd, "git stash push --include-untracked -m '"), "' -- 'unstaged.go' 'new.go'")
+			case strings.HasPrefix(cmd, "git stash list"):
+				return "stash@{0}\x1fnow\x1fOn main: other\nstash@{1}\x1fnow\x1fOn main: " + stashed + "\n", nil
+			}
+			return "", nil
+		},
+	}
+
+	message, err := handlers.StashUnselectedChanges(mock, []string{"unstaged.go", "new.go"})
+	require.NoError(t, err)
+	assert.Equal(t, stashed, message)
+	assert.True(t, strings.HasPrefix(message, "git-commit-ui: changes not selected for commit"))
+
+	require.NoError(t, handlers.RestoreUnselectedChanges(mock, message))
+	assert.Equal(t, "git stash pop stash@{1}", executed[len(executed)-1])
+}
+
+func TestRestoreUnselectedChangesConflict(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			if strings.HasPrefix(cmd, "git stash list") {
+				return "stash@{0}\x1fnow\x1fOn main: kept\n", nil
+			}
+			return "CONFLICT (content)", errors.New("exit status 1")
+		},
+	}
+
+	err := handlers.RestoreUnselectedChanges(mock, "kept")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kept in stash@{0}")
+
+	assert.Error(t, handlers.RestoreUnselectedChanges(mock, "missing"))
+}
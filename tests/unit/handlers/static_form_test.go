@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/stretchr/testify/assert"
+)
+
+// static_form.go methods
+func TestStaticCommitFormValues(t *testing.T) {
+	form := &handlers.StaticCommitForm{Version: " 1.x ", CommitType: "feat", Jira: "SS-1", Summary: "Add login ", Body: "With remember me\n"}
+	form.SetDefaultValues([]string{"feat", "fix"}, "fix", "2.x", "SS-9")
+
+	assert.NoError(t, form.Run())
+
+	version, commitType, jira, summary := form.GetValues()
+	assert.Equal(t, "1.x", version)
+	assert.Equal(t, "feat", commitType)
+	assert.Equal(t, "SS-1", jira)
+	assert.Equal(t, "Add login\n\nWith remember me", summary)
+}
+
+func TestStaticCommitFormIgnoresDefaults(t *testing.T) {
+	form := &handlers.StaticCommitForm{CommitType: "fix", Summary: "Fix crash"}
+	form.SetDefaultValues([]string{"feat", "fix"}, "feat", "2.x", "SS-9")
+
+	version, commitType, jira, summary := form.GetValues()
+	assert.Equal(t, "", version)
+	assert.Equal(t, "fix", commitType)
+	assert.Equal(t, "", jira)
+	assert.Equal(t, "Fix crash", summary)
+}
+
+func TestStaticCommitFormValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		form handlers.StaticCommitForm
+		err  string
+	}{
+		{"missing type", handlers.StaticCommitForm{Summary: "Add login"}, "missing commit type"},
+		{"unknown type", handlers.StaticCommitForm{CommitType: "feature", Summary: "Add login"}, `invalid commit type "feature"`},
+		{"missing summary", handlers.StaticCommitForm{CommitType: "feat", Summary: "  "}, "missing summary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.SetDefaultValues([]string{"feat", "fix"}, "", "", "")
+			assert.ErrorContains(t, tt.form.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stats.go methods
+const mockNumstat = "\x1eaaaa1111 bbbb2222\n\n10\t2\tsrc/login.go\n\x1ebbbb2222 cccc3333\n\n5\t0\tsrc/login.go\n3\t1\tsrc/form.go\n-\t-\tlogo.png\n\x1ecccc3333 dddd4444 eeee5555\n"
+
+func TestBuildStats(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.True(t, strings.HasSuffix(cmd, " HEAD"), cmd)
+			if strings.Contains(cmd, "--numstat") {
+				return mockNumstat, nil
+			}
+			return mockReportLog, nil
+		},
+	}
+
+	stats, err := handlers.BuildStats(mock, newReleaseConfig(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "HEAD", stats.Range)
+	assert.Equal(t, 3, stats.Commits)
+	assert.Equal(t, 1, stats.NonConforming)
+	assert.InDelta(t, 1.0/3, stats.NonConformingRatio, 0.001)
+	// cccc3333 is a merge commit and left out of the averages
+	assert.InDelta(t, 2.0, stats.AverageFiles, 0.001)
+	assert.InDelta(t, 10.5, stats.AverageLines, 0.001)
+
+	assert.Equal(t, []handlers.StatCount{{Name: "feat", Count: 1}, {Name: "fix", Count: 1}}, stats.ByType)
+	assert.Equal(t, []handlers.StatCount{{Name: "John Roe", Count: 2}, {Name: "Jane Doe", Count: 1}}, stats.ByAuthor)
+	assert.Equal(t, []handlers.StatCount{{Name: "SS-1", Count: 2}, {Name: "SS-2", Count: 1}}, stats.ByReference)
+	assert.Equal(t, []handlers.StatCount{{Name: "2025-W01", Count: 3}}, stats.ByWeek)
+
+	assert.Contains(t, stats.Summary(), "Non-conforming messages: 1 (33.3%)")
+
+	encoded, err := stats.JSON()
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, float64(3), decoded["commits"])
+}
+
+func TestBuildStatsEmpty(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			return "", nil
+		},
+	}
+
+	stats, err := handlers.BuildStats(mock, newReleaseConfig(), "v1.0..HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Commits)
+	assert.Zero(t, stats.NonConformingRatio)
+	assert.Empty(t, stats.ByType)
+}
+
+func TestBarChart(t *testing.T) {
+	chart := handlers.BarChart([]handlers.StatCount{{Name: "feat", Count: 4}, {Name: "fix", Count: 1}}, 8)
+	assert.Equal(t, "feat ████████ 4\nfix  ██ 1\n", chart)
+	assert.Equal(t, "No data.\n", handlers.BarChart(nil, 8))
+}
@@ -0,0 +1,62 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// suggest_type.go methods
+func newTypeRuleConfig() *settings.Config {
+	return &settings.Config{
+		CommitTypes:       []string{"feat", "docs", "test", "ci"},
+		DefaultCommitType: "feat",
+		TypeRules: []settings.TypeRule{
+			{Type: "docs", Globs: []string{"*.md", "docs/**"}},
+			{Type: "test", Globs: []string{"*_test.go"}},
+			{Type: "ci", Globs: []string{".github/workflows/**"}},
+		},
+	}
+}
+
+func TestSuggestCommitTypeMatchesRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		expected string
+	}{
+		{"markdown only", []string{"README.md", "docs/guide.md"}, "docs"},
+		{"docs folder", []string{"docs/images/diagram.png"}, "docs"},
+		{"tests only", []string{"src/app_test.go", "pkg/x_test.go"}, "test"},
+		{"ci only", []string{".github/workflows/build.yml"}, "ci"},
+		{"renamed markdown", []string{"OLD.md -> NEW.md"}, "docs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commitType, reason := handlers.SuggestCommitType(newTypeRuleConfig(), tt.files)
+			assert.Equal(t, tt.expected, commitType)
+			assert.Contains(t, reason, tt.expected)
+		})
+	}
+}
+
+func TestSuggestCommitTypeFallsBackToDefault(t *testing.T) {
+	commitType, reason := handlers.SuggestCommitType(newTypeRuleConfig(), []string{"README.md", "main.go"})
+	assert.Equal(t, "feat", commitType)
+	assert.Empty(t, reason)
+
+	commitType, reason = handlers.SuggestCommitType(newTypeRuleConfig(), nil)
+	assert.Equal(t, "feat", commitType)
+	assert.Empty(t, reason)
+}
+
+func TestSuggestCommitTypeIgnoresUnknownTypes(t *testing.T) {
+	config := newTypeRuleConfig()
+	config.CommitTypes = []string{"feat", "test"}
+
+	commitType, _ := handlers.SuggestCommitType(config, []string{"README.md"})
+	assert.Equal(t, "feat", commitType)
+}
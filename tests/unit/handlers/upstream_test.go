@@ -0,0 +1,68 @@
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upstream.go methods
+func TestGetUpstream(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			switch cmd {
+			case "git config --get branch.login.remote":
+				return "upstream\n", nil
+			case "git config --get branch.login.merge":
+				return "refs/heads/feature/login\n", nil
+			}
+			return "", errors.New("not set")
+		},
+	}
+
+	upstream := handlers.GetUpstream(mock, "login")
+	require.NotNil(t, upstream)
+	assert.Equal(t, "upstream/feature/login", upstream.Name())
+
+	assert.Nil(t, handlers.GetUpstream(mock, "main"))
+}
+
+func TestUpdateAheadBehind(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git rev-list --left-right --count HEAD...origin/main", cmd)
+			return "2\t1\n", nil
+		},
+	}
+
+	upstream := &handlers.Upstream{Remote: "origin", Branch: "main"}
+	require.NoError(t, handlers.UpdateAheadBehind(mock, upstream))
+	assert.Equal(t, 2, upstream.Ahead)
+	assert.Equal(t, 1, upstream.Behind)
+
+	mock.ExecuteCommandFunc = func(cmd string) (string, error) { return "garbage", nil }
+	assert.Error(t, handlers.UpdateAheadBehind(mock, upstream))
+}
+
+func TestFetchRemote(t *testing.T) {
+	mock := &MockGitHelper{
+		ExecuteCommandFunc: func(cmd string) (string, error) {
+			assert.Equal(t, "git fetch origin", cmd)
+			return "fatal: unable to access", errors.New("exit status 128")
+		},
+	}
+
+	err := handlers.FetchRemote(mock, "origin")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to access")
+}
+
+func TestDescribeUpstream(t *testing.T) {
+	assert.Equal(t, "Branch 'main' has no upstream yet, it will be set by the push", handlers.DescribeUpstream("main", nil))
+	assert.Equal(t, "Branch 'main' tracks 'origin/main': up to date", handlers.DescribeUpstream("main", &handlers.Upstream{Remote: "origin", Branch: "main"}))
+	assert.Equal(t, "Branch 'main' tracks 'origin/main': 2 ahead", handlers.DescribeUpstream("main", &handlers.Upstream{Remote: "origin", Branch: "main", Ahead: 2}))
+	assert.Equal(t, "Branch 'main' tracks 'origin/main': 2 ahead, 3 behind", handlers.DescribeUpstream("main", &handlers.Upstream{Remote: "origin", Branch: "main", Ahead: 2, Behind: 3}))
+}
@@ -36,6 +36,33 @@ func TestParseCommand(t *testing.T) {
 	}
 }
 
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		file     string
+		expected bool
+	}{
+		{"*.md", "README.md", true},
+		{"*.md", "docs/guide.md", true},
+		{"*.md", "main.go", false},
+		{"*_test.go", "src/app_test.go", true},
+		{"docs/**", "docs/a/b/c.png", true},
+		{"docs/**", "src/docs/a.png", false},
+		{".github/workflows/**", ".github/workflows/ci.yml", true},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "src/sub/main.go", false},
+		{"src/**/*.go", "src/main.go", true},
+		{"src/**/*.go", "src/a/b/main.go", true},
+		{"", "main.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.file, func(t *testing.T) {
+			assert.Equal(t, tt.expected, helpers.MatchGlob(tt.pattern, tt.file))
+		})
+	}
+}
+
 func TestExecuteCommandSuccess(t *testing.T) {
 	output, err := helpers.ExecuteCommand("ls")
 	if err != nil {
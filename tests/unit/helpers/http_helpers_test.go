@@ -0,0 +1,63 @@
+package helpers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.Write([]byte(`{"name":"created"}`))
+	}))
+	defer server.Close()
+
+	request, err := helpers.NewJSONRequest(context.Background(), http.MethodPost, server.URL, map[string]string{"name": "new"})
+	require.NoError(t, err)
+
+	var result struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, helpers.SendJSON(server.Client(), request, "Service", &result))
+	assert.Equal(t, "created", result.Name)
+}
+
+func TestSendJSONErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "validation failed", http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	request, err := helpers.NewJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	err = helpers.SendJSON(server.Client(), request, "Service", nil)
+	var status *helpers.StatusError
+	require.True(t, errors.As(err, &status))
+	assert.Equal(t, http.StatusUnprocessableEntity, status.StatusCode)
+	assert.Equal(t, "Service returned 422 Unprocessable Entity: validation failed", err.Error())
+}
+
+func TestSendJSONUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	request, err := helpers.NewJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, helpers.SendJSON(http.DefaultClient, request, "Service", nil), helpers.ErrUnreachable)
+}
+
+func TestEnvOrDefault(t *testing.T) {
+	t.Setenv("GIT_COMMIT_UI_TEST_VALUE", " from-env ")
+	assert.Equal(t, "from-env", helpers.EnvOrDefault("GIT_COMMIT_UI_TEST_VALUE", "fallback"))
+	assert.Equal(t, "fallback", helpers.EnvOrDefault("GIT_COMMIT_UI_TEST_UNSET", "fallback"))
+}
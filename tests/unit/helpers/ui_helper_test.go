@@ -1,6 +1,7 @@
 package helpers_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/kurianvarkey/gitcommitui/src/helpers"
@@ -23,6 +24,113 @@ func TestShowConfirmMocked(t *testing.T) {
 	}
 }
 
+func TestShowSelectMocked(t *testing.T) {
+	original := helpers.GetSelectPromptFunc()
+	defer helpers.SetSelectPromptFunc(original)
+
+	helpers.SetSelectPromptFunc(func(title string, options []helpers.SelectOption) (string, bool) {
+		if title != "Pick one" {
+			t.Errorf("Expected title to be 'Pick one', got %s", title)
+		}
+		return options[0].Value, true
+	})
+
+	value, ok := helpers.ShowSelect("Pick one", []helpers.SelectOption{{Label: "A", Value: "a"}})
+	if !ok || value != "a" {
+		t.Errorf("Expected ShowSelect to return 'a', got %q", value)
+	}
+
+	if _, ok := helpers.ShowSelect("Pick one", nil); ok {
+		t.Errorf("Expected ShowSelect without options to return false")
+	}
+}
+
+func TestShowMultiSelectMocked(t *testing.T) {
+	original := helpers.GetMultiSelectPromptFunc()
+	defer helpers.SetMultiSelectPromptFunc(original)
+
+	helpers.SetMultiSelectPromptFunc(func(title string, options []helpers.SelectOption, selected []string) ([]string, bool) {
+		if len(selected) != 1 || selected[0] != "b" {
+			t.Errorf("Expected 'b' to be preselected, got %v", selected)
+		}
+		return []string{options[0].Value, options[1].Value}, true
+	})
+
+	values, ok := helpers.ShowMultiSelect("Pick some", []helpers.SelectOption{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}}, []string{"b"})
+	if !ok || len(values) != 2 {
+		t.Errorf("Expected ShowMultiSelect to return two values, got %v", values)
+	}
+
+	if _, ok := helpers.ShowMultiSelect("Pick some", nil, nil); ok {
+		t.Errorf("Expected ShowMultiSelect without options to return false")
+	}
+}
+
+func TestShowInputMocked(t *testing.T) {
+	original := helpers.GetInputPromptFunc()
+	defer helpers.SetInputPromptFunc(original)
+
+	helpers.SetInputPromptFunc(func(title string, value string) (string, bool) {
+		return value + "-edited", true
+	})
+
+	value, ok := helpers.ShowInput("Name", "draft")
+	if !ok || value != "draft-edited" {
+		t.Errorf("Expected ShowInput to return 'draft-edited', got %q", value)
+	}
+}
+
+func TestCopyToClipboardMocked(t *testing.T) {
+	original := helpers.GetClipboardWriteFunc()
+	defer helpers.SetClipboardWriteFunc(original)
+
+	var copied string
+	helpers.SetClipboardWriteFunc(func(text string) error {
+		copied = text
+		return nil
+	})
+
+	if err := helpers.CopyToClipboard("abc123"); err != nil || copied != "abc123" {
+		t.Errorf("Expected 'abc123' to be copied, got %q (%v)", copied, err)
+	}
+}
+
+func TestDisablePrompts(t *testing.T) {
+	originalConfirm, originalSelect := helpers.GetConfirmPromptFunc(), helpers.GetSelectPromptFunc()
+	originalMultiSelect, originalInput := helpers.GetMultiSelectPromptFunc(), helpers.GetInputPromptFunc()
+	defer func() {
+		helpers.SetConfirmPromptFunc(originalConfirm)
+		helpers.SetSelectPromptFunc(originalSelect)
+		helpers.SetMultiSelectPromptFunc(originalMultiSelect)
+		helpers.SetInputPromptFunc(originalInput)
+	}()
+
+	helpers.DisablePrompts(false)
+	if !helpers.ShowConfirm("Commit?") || !helpers.ShowConfirm("Push?", true) || helpers.ShowConfirm("Force push?", false) {
+		t.Errorf("Expected confirmations to take their default answer, and yes without one")
+	}
+	if _, ok := helpers.ShowSelect("Pick", []helpers.SelectOption{{Label: "A", Value: "a"}}); ok {
+		t.Errorf("Expected ShowSelect to be canceled")
+	}
+	if _, ok := helpers.ShowMultiSelect("Pick", []helpers.SelectOption{{Label: "A", Value: "a"}}, nil); ok {
+		t.Errorf("Expected ShowMultiSelect to be canceled")
+	}
+	if _, ok := helpers.ShowInput("Name", "draft"); ok {
+		t.Errorf("Expected ShowInput to be canceled")
+	}
+	if err := helpers.RequireConfirmation("Initialise?"); !errors.Is(err, helpers.ErrConfirmationRequired) {
+		t.Errorf("Expected the confirmation to be required without yes, got %v", err)
+	}
+
+	helpers.DisablePrompts(true)
+	if !helpers.ShowConfirm("Force push?", false) {
+		t.Errorf("Expected every confirmation to be accepted with yes")
+	}
+	if err := helpers.RequireConfirmation("Initialise?"); err != nil {
+		t.Errorf("Expected no confirmation to be required with yes, got %v", err)
+	}
+}
+
 func TestShowSpinnerRunsAction(t *testing.T) {
 	called := false
 	helpers.ShowSpinner("Testing...", func() {
@@ -0,0 +1,73 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useConfigFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	settings.SetConfigFile(path)
+	t.Cleanup(func() { settings.SetConfigFile("") })
+	return path
+}
+
+func TestSetConfigFile(t *testing.T) {
+	path := useConfigFile(t)
+	assert.Equal(t, path, settings.ConfigFile())
+
+	settings.SetConfigFile("")
+	assert.Equal(t, settings.DefaultConfigFile, settings.ConfigFile())
+}
+
+func TestInitConfigAndValidateDefaults(t *testing.T) {
+	path := useConfigFile(t)
+
+	require.NoError(t, settings.InitConfig(false))
+	assert.FileExists(t, path)
+	assert.Error(t, settings.InitConfig(false), "an existing file is kept without force")
+	assert.NoError(t, settings.InitConfig(true))
+
+	problems, err := settings.ValidateConfigFile()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestValidateConfigFileUnknownKey(t *testing.T) {
+	path := useConfigFile(t)
+	require.NoError(t, os.WriteFile(path, []byte(`{"commit_formt": "$summary"}`), 0644))
+
+	_, err := settings.ValidateConfigFile()
+	assert.ErrorContains(t, err, "commit_formt")
+}
+
+func TestValidateConfig(t *testing.T) {
+	config := &settings.Config{
+		CommitFormat:      "[$type] no summary",
+		CommitTypes:       []string{"feat", "fix"},
+		DefaultCommitType: "chore",
+		TypeRules:         []settings.TypeRule{{Type: "docs", Globs: []string{"[docs"}}},
+		IssueTracker:      "trello",
+		Signing:           settings.Signing{Sign: "sometimes"},
+		Push:              settings.Push{PullStrategy: "squash"},
+		Changelog:         settings.Changelog{Sections: []settings.ChangelogSection{{Type: "feat"}}},
+	}
+
+	problems := settings.ValidateConfig(config)
+	assert.Equal(t, []string{
+		"commit_format must contain $summary",
+		`default_commit_type "chore" is not one of commit_types`,
+		`type_rules[0]: type "docs" is not one of commit_types`,
+		`type_rules[0]: invalid glob "[docs"`,
+		`issue_tracker "trello" must be jira, github or gitlab`,
+		`signing.sign "sometimes" must be auto, always or never`,
+		`push.pull_strategy "squash" must be rebase or merge`,
+		"changelog.sections[0] needs a type and a title",
+	}, problems)
+}
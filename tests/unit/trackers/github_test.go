@@ -0,0 +1,94 @@
+package trackers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitHubStandIn starts a local HTTP server that behaves like the parts of
+// the GitHub REST API used by the client.
+func newGitHubStandIn(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/issues/123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"number":123,"title":"Fix crash","state":"open"}`))
+	})
+	mux.HandleFunc("/repos/other/repo/issues/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"number":7,"title":"Other","state":"closed"}`))
+	})
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repo:owner/repo is:issue is:open assignee:@me crash", r.URL.Query().Get("q"))
+		w.Write([]byte(`{"items":[{"number":123,"title":"Fix crash","state":"open"}]}`))
+	})
+	mux.HandleFunc("/", http.NotFound)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestGitHubClient(baseURL string) *trackers.GitHubClient {
+	return trackers.NewGitHubClient(settings.Forge{BaseURL: baseURL, Token: "secret"}, "git@github.com:owner/repo.git")
+}
+
+func TestGitHubValidReference(t *testing.T) {
+	client := newTestGitHubClient("")
+	assert.True(t, client.ValidReference("#123"))
+	assert.True(t, client.ValidReference("owner/repo#123"))
+	assert.False(t, client.ValidReference("group/sub/project#1"))
+	assert.False(t, client.ValidReference("SS-123"))
+}
+
+func TestGitHubIssueURL(t *testing.T) {
+	client := newTestGitHubClient("")
+	assert.Equal(t, "https://github.com/owner/repo/issues/123", client.IssueURL("#123"))
+	assert.Equal(t, "https://github.com/other/repo/issues/7", client.IssueURL("other/repo#7"))
+	assert.Empty(t, client.IssueURL("SS-123"))
+
+	enterprise := newTestGitHubClient("https://github.example.com/api/v3/")
+	assert.Equal(t, "https://github.example.com/owner/repo/issues/123", enterprise.IssueURL("#123"))
+}
+
+func TestGitHubGetIssue(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	client := newTestGitHubClient(newGitHubStandIn(t).URL)
+
+	issue, err := client.GetIssue(context.Background(), "#123")
+	require.NoError(t, err)
+	assert.Equal(t, &trackers.Issue{Key: "#123", Title: "Fix crash", Status: "open"}, issue)
+
+	issue, err = client.GetIssue(context.Background(), "other/repo#7")
+	require.NoError(t, err)
+	assert.Equal(t, "closed", issue.Status)
+
+	_, err = client.GetIssue(context.Background(), "#999")
+	assert.ErrorIs(t, err, trackers.ErrIssueNotFound)
+}
+
+func TestGitHubGetIssueWithoutToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	client := trackers.NewGitHubClient(settings.Forge{BaseURL: newGitHubStandIn(t).URL}, "git@github.com:owner/repo.git")
+
+	_, err := client.GetIssue(context.Background(), "#999")
+	assert.ErrorIs(t, err, trackers.ErrUnavailable)
+	assert.NotErrorIs(t, err, trackers.ErrIssueNotFound)
+}
+
+func TestGitHubSearchIssues(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	client := newTestGitHubClient(newGitHubStandIn(t).URL)
+
+	issues, err := client.SearchIssues(context.Background(), "crash")
+	require.NoError(t, err)
+	assert.Equal(t, []trackers.Issue{{Key: "#123", Title: "Fix crash", Status: "open"}}, issues)
+}
@@ -0,0 +1,78 @@
+package trackers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitLabStandIn starts a local HTTP server that behaves like the parts of
+// the GitLab REST API used by the client.
+func newGitLabStandIn(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fproject/issues/12":
+			w.Write([]byte(`{"iid":12,"title":"Broken build","state":"opened"}`))
+		case "/api/v4/projects/group%2Fsub%2Fother/issues/3":
+			w.Write([]byte(`{"iid":3,"title":"Elsewhere","state":"closed"}`))
+		case "/api/v4/projects/group%2Fproject/issues":
+			assert.Equal(t, "assigned_to_me", r.URL.Query().Get("scope"))
+			assert.Equal(t, "build", r.URL.Query().Get("search"))
+			w.Write([]byte(`[{"iid":12,"title":"Broken build","state":"opened"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestGitLabClient(baseURL string) *trackers.GitLabClient {
+	return trackers.NewGitLabClient(settings.Forge{BaseURL: baseURL, Project: "group/project", Token: "secret"}, "")
+}
+
+func TestGitLabIssueURL(t *testing.T) {
+	client := newTestGitLabClient("https://gitlab.example.com/")
+	assert.Equal(t, "https://gitlab.example.com/group/project/-/issues/12", client.IssueURL("#12"))
+	assert.Equal(t, "https://gitlab.example.com/other/sub/project/-/issues/3", client.IssueURL("other/sub/project#3"))
+	assert.Empty(t, client.IssueURL("SS-12"))
+}
+
+func TestGitLabGetIssue(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "")
+	client := newTestGitLabClient(newGitLabStandIn(t).URL)
+
+	issue, err := client.GetIssue(context.Background(), "#12")
+	require.NoError(t, err)
+	assert.Equal(t, &trackers.Issue{Key: "#12", Title: "Broken build", Status: "opened"}, issue)
+
+	issue, err = client.GetIssue(context.Background(), "group/sub/other#3")
+	require.NoError(t, err)
+	assert.Equal(t, "Elsewhere", issue.Title)
+
+	_, err = client.GetIssue(context.Background(), "#404")
+	assert.ErrorIs(t, err, trackers.ErrIssueNotFound)
+
+	assert.True(t, client.ValidReference("group/sub/other#3"))
+	assert.False(t, client.ValidReference("SS-3"))
+}
+
+func TestGitLabSearchIssues(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "")
+	client := newTestGitLabClient(newGitLabStandIn(t).URL)
+
+	issues, err := client.SearchIssues(context.Background(), "#build")
+	require.NoError(t, err)
+	assert.Equal(t, []trackers.Issue{{Key: "#12", Title: "Broken build", Status: "opened"}}, issues)
+}
@@ -0,0 +1,106 @@
+package trackers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJiraStandIn starts a local HTTP server that behaves like the parts of the
+// Jira REST API used by the client.
+func newJiraStandIn(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/issue/SS-1234", func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "me@example.com", user)
+		assert.Equal(t, "secret", token)
+		w.Write([]byte(`{"key":"SS-1234","fields":{"summary":"Add login page","status":{"name":"In Progress"}}}`))
+	})
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		assert.True(t, strings.HasPrefix(jql, "assignee = currentUser()"))
+		assert.Contains(t, jql, `summary ~ "login*"`)
+		w.Write([]byte(`{"issues":[{"key":"SS-1234","fields":{"summary":"Add login page","status":{"name":"In Progress"}}}]}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestJiraClient(baseURL string) *trackers.JiraClient {
+	return trackers.NewJiraClient(settings.Jira{BaseURL: baseURL + "/", Email: "me@example.com", Token: "secret"})
+}
+
+func TestNewJiraClientDisabledWithoutBaseURL(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "")
+	assert.Nil(t, trackers.NewJiraClient(settings.Jira{}))
+	assert.Nil(t, trackers.New(&settings.Config{}, ""))
+}
+
+func TestNewJiraClientUsesEnvironment(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "https://jira.example.com")
+	t.Setenv("JIRA_API_TOKEN", "from-env")
+
+	client := trackers.NewJiraClient(settings.Jira{Token: "from-config"})
+	require.NotNil(t, client)
+	assert.Equal(t, "https://jira.example.com", client.BaseURL)
+	assert.Equal(t, "from-env", client.Token)
+}
+
+func TestJiraIssueURL(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "")
+	client := newTestJiraClient("https://jira.example.com")
+	assert.Equal(t, "https://jira.example.com/browse/SS-1234", client.IssueURL("ss-1234"))
+	assert.Empty(t, client.IssueURL("#12"))
+}
+
+func TestJiraGetIssue(t *testing.T) {
+	client := newTestJiraClient(newJiraStandIn(t).URL)
+
+	issue, err := client.GetIssue(context.Background(), "ss-1234")
+	require.NoError(t, err)
+	assert.Equal(t, &trackers.Issue{Key: "SS-1234", Title: "Add login page", Status: "In Progress"}, issue)
+}
+
+func TestJiraGetIssueNotFound(t *testing.T) {
+	client := newTestJiraClient(newJiraStandIn(t).URL)
+
+	_, err := client.GetIssue(context.Background(), "SS-12345")
+	assert.ErrorIs(t, err, trackers.ErrIssueNotFound)
+
+	_, err = client.GetIssue(context.Background(), "not a key")
+	assert.ErrorIs(t, err, trackers.ErrIssueNotFound)
+}
+
+func TestJiraGetIssueOffline(t *testing.T) {
+	server := newJiraStandIn(t)
+	client := newTestJiraClient(server.URL)
+	server.Close()
+
+	_, err := client.GetIssue(context.Background(), "SS-1234")
+	assert.ErrorIs(t, err, trackers.ErrUnavailable)
+}
+
+func TestJiraSearchIssues(t *testing.T) {
+	client := newTestJiraClient(newJiraStandIn(t).URL)
+
+	issues, err := client.SearchIssues(context.Background(), "login")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "SS-1234", issues[0].Key)
+}
@@ -0,0 +1,54 @@
+package trackers_test
+
+import (
+	"testing"
+
+	"github.com/kurianvarkey/gitcommitui/src/settings"
+	"github.com/kurianvarkey/gitcommitui/src/trackers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChoosesConfiguredTracker(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "")
+
+	config := &settings.Config{IssueTracker: settings.TrackerGitHub}
+	assert.Equal(t, "GitHub", trackers.New(config, "git@github.com:owner/repo.git").Name())
+
+	config = &settings.Config{IssueTracker: settings.TrackerGitLab, GitLab: settings.Forge{Project: "group/sub/project"}}
+	assert.Equal(t, "GitLab", trackers.New(config, "").Name())
+
+	config = &settings.Config{IssueTracker: settings.TrackerJira, Jira: settings.Jira{BaseURL: "https://jira.example.com"}}
+	assert.Equal(t, "Jira", trackers.New(config, "").Name())
+
+	assert.Nil(t, trackers.New(&settings.Config{IssueTracker: settings.TrackerGitHub}, "not a remote"))
+}
+
+func TestProjectFromRemoteURL(t *testing.T) {
+	tests := map[string]string{
+		"git@github.com:owner/repo.git":                           "owner/repo",
+		"https://github.com/owner/repo.git":                       "owner/repo",
+		"https://github.com/owner/repo":                           "owner/repo",
+		"ssh://git@gitlab.example.com:2222/group/sub/project.git": "group/sub/project",
+		"https://user@gitlab.com/group/project/":                  "group/project",
+		"mocked output":                                           "",
+		"":                                                        "",
+	}
+
+	for remoteURL, expected := range tests {
+		assert.Equal(t, expected, trackers.ProjectFromRemoteURL(remoteURL), remoteURL)
+	}
+}
+
+func TestClosingTrailer(t *testing.T) {
+	config := &settings.Config{IssueTracker: settings.TrackerGitHub, CloseIssues: true}
+	assert.Equal(t, "Closes #123", trackers.ClosingTrailer(config, "#123"))
+	assert.Equal(t, "Closes owner/repo#9", trackers.ClosingTrailer(config, "owner/repo#9"))
+	assert.Empty(t, trackers.ClosingTrailer(config, "SS-1"))
+	assert.Empty(t, trackers.ClosingTrailer(config, ""))
+
+	config.CloseIssues = false
+	assert.Empty(t, trackers.ClosingTrailer(config, "#123"))
+
+	jira := &settings.Config{IssueTracker: settings.TrackerJira, CloseIssues: true}
+	assert.Empty(t, trackers.ClosingTrailer(jira, "SS-1"))
+}